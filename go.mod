@@ -3,6 +3,8 @@ module github.com/mango-habanero/otc
 go 1.25.0
 
 require (
+	github.com/godbus/dbus/v5 v5.1.0
+	go.uber.org/goleak v1.3.0
 	google.golang.org/grpc v1.76.0
 	k8s.io/cri-api v0.34.1
 )
@@ -0,0 +1,65 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// containerdAppArmorProfile is the profile containerd's CRI plugin applies to
+// containers that don't request one, unless AppArmor is disabled in its config.
+const containerdAppArmorProfile = "cri-containerd.apparmor.d"
+
+// apparmorDisabled is reported when the runtime is configured not to use AppArmor.
+const apparmorDisabled = "disabled"
+
+// criAppArmorProfile derives the default AppArmor profile from a CRI config.
+// An explicit apparmorProfile setting wins; otherwise containerd's built-in
+// default applies unless disableApparmor is set. Returns "" when the config
+// doesn't mention AppArmor.
+func criAppArmorProfile(cfg criConfig) string {
+	if profile := cfg.str("apparmorProfile"); profile != "" {
+		return profile
+	}
+
+	value, ok := cfg.lookup("disableApparmor")
+	if !ok {
+		return ""
+	}
+	if disabled, _ := value.(bool); disabled {
+		return apparmorDisabled
+	}
+	return containerdAppArmorProfile
+}
+
+// hostAppArmorEnabled reports whether the AppArmor LSM is enabled in the kernel,
+// read from sysRoot/module/apparmor/parameters/enabled.
+func hostAppArmorEnabled(sysRoot string) bool {
+	data, err := os.ReadFile(filepath.Join(sysRoot, "module", "apparmor", "parameters", "enabled"))
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(data)) == "Y"
+}
+
+// checkAppArmor warns when the host enforces AppArmor but a runtime won't apply it:
+// a CRI runtime with AppArmor disabled, or an OCI runtime built without support.
+// Containers then run unconfined. Skipped when the host has AppArmor off.
+func checkAppArmor(runtimes []Runtime, sysRoot string) []error {
+	if !hostAppArmorEnabled(sysRoot) {
+		return nil
+	}
+
+	var warnings []error
+	for _, rt := range runtimes {
+		switch {
+		case rt.Capabilities["apparmorProfile"] == apparmorDisabled:
+			warnings = append(warnings, newWarning(SeverityMedium,
+				"host enforces AppArmor but %s has it disabled; containers run unconfined", rt.Name))
+		case rt.Capabilities["apparmor"] == "false":
+			warnings = append(warnings, newWarning(SeverityMedium,
+				"host enforces AppArmor but %s was built without AppArmor support", rt.Name))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,96 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeAppArmorEnabled creates a fake sysfs tree reporting the AppArmor LSM state.
+func writeAppArmorEnabled(t *testing.T, sysRoot, value string) {
+	t.Helper()
+
+	dir := filepath.Join(sysRoot, "module", "apparmor", "parameters")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create sysfs dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "enabled"), []byte(value+"\n"), 0644); err != nil {
+		t.Fatalf("failed to write apparmor state: %v", err)
+	}
+}
+
+func TestCriAppArmorProfile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{name: "explicit profile", config: `{"apparmorProfile": "crio-default"}`, want: "crio-default"},
+		{name: "containerd default", config: `{"disableApparmor": false}`, want: containerdAppArmorProfile},
+		{name: "disabled", config: `{"disableApparmor": true}`, want: apparmorDisabled},
+		{name: "not reported", config: `{"sandboxImage": "registry.k8s.io/pause:3.9"}`, want: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := parseCRIConfig(map[string]string{"config": tt.config})
+			if err != nil {
+				t.Fatalf("parseCRIConfig() error = %v", err)
+			}
+			if got := criAppArmorProfile(cfg); got != tt.want {
+				t.Errorf("criAppArmorProfile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_AppArmorProfile(t *testing.T) {
+	t.Parallel()
+
+	rt := detectWithConfig(t, `{"disableApparmor": false}`)
+	if got := rt.Capabilities["apparmorProfile"]; got != containerdAppArmorProfile {
+		t.Errorf("apparmorProfile = %q, want %q", got, containerdAppArmorProfile)
+	}
+}
+
+func TestCheckAppArmor(t *testing.T) {
+	t.Parallel()
+
+	runtimes := []Runtime{
+		{Name: Containerd, Type: TypeCRI, Capabilities: map[string]string{"apparmorProfile": apparmorDisabled}},
+		{Name: Runc, Type: TypeOCI, Capabilities: map[string]string{"apparmor": "true"}},
+		{Name: Crun, Type: TypeOCI, Capabilities: map[string]string{"apparmor": "false"}},
+		{Name: Youki, Type: TypeOCI},
+	}
+
+	tests := []struct {
+		name         string
+		hostState    string
+		wantWarnings int
+	}{
+		{name: "host enforces apparmor", hostState: "Y", wantWarnings: 2},
+		{name: "host apparmor off", hostState: "N", wantWarnings: 0},
+		{name: "no apparmor module", hostState: "", wantWarnings: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sysRoot := t.TempDir()
+			if tt.hostState != "" {
+				writeAppArmorEnabled(t, sysRoot, tt.hostState)
+			}
+
+			if got := checkAppArmor(runtimes, sysRoot); len(got) != tt.wantWarnings {
+				t.Errorf("checkAppArmor() = %v, want %d warnings", got, tt.wantWarnings)
+			}
+		})
+	}
+}
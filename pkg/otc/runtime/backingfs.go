@@ -0,0 +1,106 @@
+package runtime
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// defaultContainerdRoot is containerd's persistent storage root when config.toml doesn't set one.
+const defaultContainerdRoot = "/var/lib/containerd"
+
+// xfsFtypePattern matches the ftype flag in xfs_info output ("naming =version 2 ... ftype=1").
+var xfsFtypePattern = regexp.MustCompile(`ftype=(\d)`)
+
+// containerdRoot returns the storage root from the top level of a containerd
+// config (before the first table), or the default when unset or unreadable.
+func containerdRoot(configPath string) string {
+	entries, err := readTOMLEntries(configPath)
+	if err != nil {
+		return defaultContainerdRoot
+	}
+	for _, e := range entries {
+		if e.table == "" && e.key == "root" && e.value != "" {
+			return e.value
+		}
+	}
+	return defaultContainerdRoot
+}
+
+// mountFsType returns the filesystem type and mount point of the mount
+// containing dir, found by longest mount point prefix in a mounts table
+// (/proc/self/mounts format). ok is false when the table can't be read.
+func mountFsType(mountsPath, dir string) (fsType, mountPoint string, ok bool) {
+	f, err := os.Open(mountsPath)
+	if err != nil {
+		return "", "", false
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	dir = filepath.Clean(dir)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		mp := strings.ReplaceAll(fields[1], `\040`, " ")
+		if !pathWithin(dir, mp) || len(mp) < len(mountPoint) {
+			continue
+		}
+		// Later entries stack on earlier ones at the same mount point
+		fsType, mountPoint, ok = fields[2], mp, true
+	}
+	return fsType, mountPoint, ok
+}
+
+// pathWithin reports whether path is dir or lies below it.
+func pathWithin(path, dir string) bool {
+	if dir == "/" || path == dir {
+		return true
+	}
+	return strings.HasPrefix(path, dir+"/")
+}
+
+// probeBackingFs reports the filesystem backing root as Capabilities["backingFs"].
+// For xfs, Capabilities["xfsFtype"] records whether the filesystem stores file
+// types in directory entries (d_type), which overlayfs requires.
+// Returns nil when the mount table is unavailable.
+func probeBackingFs(ctx context.Context, mountsPath, root string, run commandRunner) map[string]string {
+	fsType, mountPoint, ok := mountFsType(mountsPath, root)
+	if !ok {
+		return nil
+	}
+
+	caps := map[string]string{"backingFs": fsType}
+	if fsType == "xfs" {
+		if output, err := run(ctx, "xfs_info", mountPoint); err == nil {
+			if m := xfsFtypePattern.FindSubmatch(output); m != nil {
+				caps["xfsFtype"] = string(m[1])
+			}
+		}
+	}
+	return caps
+}
+
+// checkBackingFs warns about storage filesystems known to break overlay snapshots:
+// xfs formatted without ftype=1, and overlay stacked on another overlay.
+func checkBackingFs(runtimes []Runtime) []error {
+	var warnings []error
+	for _, rt := range runtimes {
+		switch {
+		case rt.Capabilities["backingFs"] == "xfs" && rt.Capabilities["xfsFtype"] == "0":
+			warnings = append(warnings, newWarning(SeverityHigh,
+				"%s storage is on xfs without ftype=1; overlayfs cannot work, reformat with mkfs.xfs -n ftype=1", rt.Name))
+		case rt.Capabilities["backingFs"] == "overlay":
+			warnings = append(warnings, newWarning(SeverityMedium,
+				"%s storage is on overlayfs; nested overlay snapshots are not supported", rt.Name))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,141 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testMounts = `sysfs /sys sysfs rw,nosuid,nodev,noexec,relatime 0 0
+proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+/dev/sda1 / ext4 rw,relatime 0 0
+/dev/sdb1 /var/lib xfs rw,relatime,attr2,inode64 0 0
+/dev/sdc1 /var/lib/containerd btrfs rw,relatime,space_cache=v2 0 0
+overlay /var/lib/docker overlay rw,relatime,lowerdir=/a,upperdir=/b,workdir=/c 0 0
+/dev/sdd1 /mnt/with\040space ext4 rw 0 0
+`
+
+func TestMountFsType(t *testing.T) {
+	t.Parallel()
+
+	mountsPath := filepath.Join(t.TempDir(), "mounts")
+	if err := os.WriteFile(mountsPath, []byte(testMounts), 0644); err != nil {
+		t.Fatalf("failed to write mounts: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		dir            string
+		wantFsType     string
+		wantMountPoint string
+	}{
+		{name: "dedicated btrfs mount", dir: "/var/lib/containerd", wantFsType: "btrfs", wantMountPoint: "/var/lib/containerd"},
+		{name: "parent xfs mount", dir: "/var/lib/crio", wantFsType: "xfs", wantMountPoint: "/var/lib"},
+		{name: "sibling prefix not matched", dir: "/var/lib/containerd2", wantFsType: "xfs", wantMountPoint: "/var/lib"},
+		{name: "overlay mount", dir: "/var/lib/docker/overlay2", wantFsType: "overlay", wantMountPoint: "/var/lib/docker"},
+		{name: "root filesystem", dir: "/opt/containerd", wantFsType: "ext4", wantMountPoint: "/"},
+		{name: "escaped mount point", dir: "/mnt/with space/data", wantFsType: "ext4", wantMountPoint: "/mnt/with space"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fsType, mountPoint, ok := mountFsType(mountsPath, tt.dir)
+			if !ok || fsType != tt.wantFsType || mountPoint != tt.wantMountPoint {
+				t.Errorf("mountFsType() = %q, %q, %v; want %q, %q, true",
+					fsType, mountPoint, ok, tt.wantFsType, tt.wantMountPoint)
+			}
+		})
+	}
+}
+
+func TestProbeBackingFs(t *testing.T) {
+	t.Parallel()
+
+	mountsPath := filepath.Join(t.TempDir(), "mounts")
+	if err := os.WriteFile(mountsPath, []byte(testMounts), 0644); err != nil {
+		t.Fatalf("failed to write mounts: %v", err)
+	}
+
+	xfsInfo := func(ftype string) commandRunner {
+		return func(_ context.Context, name string, args ...string) ([]byte, error) {
+			if name != "xfs_info" || len(args) != 1 || args[0] != "/var/lib" {
+				return nil, errors.New("unexpected command")
+			}
+			return []byte("naming   =version 2              bsize=4096   ascii-ci=0, ftype=" + ftype + "\n"), nil
+		}
+	}
+	failing := func(context.Context, string, ...string) ([]byte, error) {
+		return nil, errors.New("xfs_info: command not found")
+	}
+
+	tests := []struct {
+		name  string
+		root  string
+		run   commandRunner
+		want  map[string]string
+		warns int
+	}{
+		{name: "xfs with ftype", root: "/var/lib/crio", run: xfsInfo("1"), want: map[string]string{"backingFs": "xfs", "xfsFtype": "1"}},
+		{name: "xfs without ftype", root: "/var/lib/crio", run: xfsInfo("0"), want: map[string]string{"backingFs": "xfs", "xfsFtype": "0"}, warns: 1},
+		{name: "xfs_info unavailable", root: "/var/lib/crio", run: failing, want: map[string]string{"backingFs": "xfs"}},
+		{name: "ext4", root: "/opt/containerd", run: failing, want: map[string]string{"backingFs": "ext4"}},
+		{name: "btrfs", root: "/var/lib/containerd", run: failing, want: map[string]string{"backingFs": "btrfs"}},
+		{name: "overlay", root: "/var/lib/docker", run: failing, want: map[string]string{"backingFs": "overlay"}, warns: 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			caps := probeBackingFs(context.Background(), mountsPath, tt.root, tt.run)
+			if !reflect.DeepEqual(caps, tt.want) {
+				t.Errorf("probeBackingFs() = %v, want %v", caps, tt.want)
+			}
+
+			warnings := checkBackingFs([]Runtime{{Name: Containerd, Type: TypeCRI, Capabilities: caps}})
+			if len(warnings) != tt.warns {
+				t.Errorf("checkBackingFs() = %v, want %d warnings", warnings, tt.warns)
+			}
+		})
+	}
+
+	if caps := probeBackingFs(context.Background(), filepath.Join(t.TempDir(), "missing"), "/var/lib/containerd", failing); caps != nil {
+		t.Errorf("probeBackingFs() without mount table = %v, want nil", caps)
+	}
+}
+
+func TestContainerdRoot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{name: "custom root", config: "version = 2\nroot = \"/data/containerd\"\n", want: "/data/containerd"},
+		{name: "root inside table ignored", config: "[plugins.foo]\n  root = \"/other\"\n", want: defaultContainerdRoot},
+		{name: "unset", config: "version = 2\n", want: defaultContainerdRoot},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(configPath, []byte(tt.config), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+			if got := containerdRoot(configPath); got != tt.want {
+				t.Errorf("containerdRoot() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
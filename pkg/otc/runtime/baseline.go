@@ -0,0 +1,164 @@
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RuntimeExpectation describes a runtime that a baseline requires.
+type RuntimeExpectation struct {
+	// Name is the runtime name (e.g. "containerd")
+	Name string
+
+	// Version optionally constrains the version. Empty accepts any version.
+	// Accepted forms, combinable with spaces (all must hold):
+	//   - "1.7.x", "1.7.*", or "1.7": matching leading components
+	//   - ">=1.7.0", ">1.6", "<=2.0", "<2", "=1.7.2": comparisons
+	Version string
+}
+
+// Matches compares the detected runtimes against a baseline and returns one
+// error per mismatch: an expected runtime that is missing, a runtime whose
+// version doesn't satisfy its constraint, or a detected runtime the baseline
+// doesn't mention. A malformed version constraint is reported as a mismatch
+// too. Returns nil when the result matches the baseline exactly.
+func (r *Result) Matches(baseline []RuntimeExpectation) []error {
+	detected := make(map[string][]Runtime)
+	for _, rt := range r.Runtimes {
+		detected[rt.Name] = append(detected[rt.Name], rt)
+	}
+
+	var errs []error
+	expected := make(map[string]bool)
+	for _, exp := range baseline {
+		expected[exp.Name] = true
+
+		candidates, ok := detected[exp.Name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("missing runtime %s", exp.Name))
+			continue
+		}
+
+		var versions []string
+		var constraintErr error
+		satisfied := false
+		for _, rt := range candidates {
+			ok, err := satisfiesVersion(rt.Version, exp.Version)
+			if err != nil {
+				constraintErr = fmt.Errorf("runtime %s: %w", exp.Name, err)
+				break
+			}
+			if ok {
+				satisfied = true
+				break
+			}
+			versions = append(versions, rt.Version)
+		}
+		switch {
+		case constraintErr != nil:
+			errs = append(errs, constraintErr)
+		case !satisfied:
+			errs = append(errs, fmt.Errorf("runtime %s version %s does not satisfy %q",
+				exp.Name, strings.Join(versions, ", "), exp.Version))
+		}
+	}
+
+	for _, rt := range r.Runtimes {
+		if !expected[rt.Name] {
+			errs = append(errs, fmt.Errorf("unexpected runtime %s %s", rt.Name, rt.Version))
+			expected[rt.Name] = true // Report each extra name once
+		}
+	}
+
+	return errs
+}
+
+// satisfiesVersion reports whether version meets every space-separated
+// constraint. A leading "v" is ignored and pre-releases sort before their
+// release. An unparseable version, e.g. "unknown", satisfies no constraint.
+// Returns error for malformed constraints.
+func satisfiesVersion(version, constraint string) (bool, error) {
+	terms := strings.Fields(constraint)
+	if len(terms) == 0 {
+		return true, nil
+	}
+
+	// Validate every term before looking at the version, so a malformed
+	// constraint is reported even when the version can't be parsed
+	checks := make([]func(semver) bool, 0, len(terms))
+	for _, c := range terms {
+		check, err := parseConstraint(c)
+		if err != nil {
+			return false, err
+		}
+		checks = append(checks, check)
+	}
+
+	v, err := parseSemver(version)
+	if err != nil {
+		return false, nil
+	}
+	for _, check := range checks {
+		if !check(v) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// parseConstraint parses one constraint term into a predicate on versions.
+// Returns error for malformed constraints.
+func parseConstraint(c string) (func(semver) bool, error) {
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		operand, ok := strings.CutPrefix(c, op)
+		if !ok {
+			continue
+		}
+		want, err := parseSemver(operand)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q", c)
+		}
+		return func(v semver) bool {
+			cmp := v.compare(want)
+			switch op {
+			case ">=":
+				return cmp >= 0
+			case "<=":
+				return cmp <= 0
+			case ">":
+				return cmp > 0
+			case "<":
+				return cmp < 0
+			default:
+				return cmp == 0
+			}
+		}, nil
+	}
+
+	// Wildcard or bare prefix: leading components must match exactly
+	prefix, ok := strings.CutSuffix(c, ".x")
+	if !ok {
+		prefix = strings.TrimSuffix(c, ".*")
+	}
+	parts := strings.Split(stripVersionPrefix(prefix), ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid version constraint %q", c)
+	}
+	want := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version constraint %q", c)
+		}
+		want[i] = n
+	}
+	return func(v semver) bool {
+		for i, n := range want {
+			if v.core[i] != n {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
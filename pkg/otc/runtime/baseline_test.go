@@ -0,0 +1,127 @@
+package runtime
+
+import (
+	"testing"
+)
+
+func TestResult_Matches(t *testing.T) {
+	t.Parallel()
+
+	result := NewResult([]Runtime{
+		{Name: Containerd, Type: TypeCRI, Version: "v1.7.13", Priority: PriorityCRI},
+		{Name: Runc, Type: TypeOCI, Version: "1.1.12", Priority: PriorityOCI},
+	}, nil)
+
+	tests := []struct {
+		name     string
+		baseline []RuntimeExpectation
+		wantErrs int
+	}{
+		{
+			name: "satisfied with wildcards",
+			baseline: []RuntimeExpectation{
+				{Name: Containerd, Version: "1.7.x"},
+				{Name: Runc, Version: "1.1.*"},
+			},
+			wantErrs: 0,
+		},
+		{
+			name: "satisfied with ranges and any version",
+			baseline: []RuntimeExpectation{
+				{Name: Containerd, Version: ">=1.7.0 <2.0"},
+				{Name: Runc},
+			},
+			wantErrs: 0,
+		},
+		{
+			name: "missing runtime",
+			baseline: []RuntimeExpectation{
+				{Name: Containerd, Version: "1.7"},
+				{Name: Runc, Version: "1.1"},
+				{Name: Crun},
+			},
+			wantErrs: 1,
+		},
+		{
+			name: "version mismatch",
+			baseline: []RuntimeExpectation{
+				{Name: Containerd, Version: "1.6.x"},
+				{Name: Runc, Version: ">=1.2.0"},
+			},
+			wantErrs: 2,
+		},
+		{
+			name: "prefix does not match longer component",
+			baseline: []RuntimeExpectation{
+				{Name: Containerd, Version: "1.7.1"},
+				{Name: Runc, Version: "=1.1.12"},
+			},
+			wantErrs: 1,
+		},
+		{
+			name:     "unexpected extras",
+			baseline: []RuntimeExpectation{{Name: Containerd}},
+			wantErrs: 1,
+		},
+		{
+			name:     "invalid constraint",
+			baseline: []RuntimeExpectation{{Name: Containerd, Version: ">="}, {Name: Runc}},
+			wantErrs: 1,
+		},
+		{
+			name:     "invalid constraint with other mismatches",
+			baseline: []RuntimeExpectation{{Name: Containerd, Version: ">="}, {Name: Crun}},
+			wantErrs: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if errs := result.Matches(tt.baseline); len(errs) != tt.wantErrs {
+				t.Errorf("Matches() = %v, want %d errors", errs, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestSatisfiesVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		version    string
+		constraint string
+		want       bool
+		wantErr    bool
+	}{
+		{name: "v prefix with wildcard", version: "v1.7.13", constraint: "1.7.x", want: true},
+		{name: "v prefix with range", version: "v1.7.13", constraint: ">=1.7.0 <2", want: true},
+		{name: "v prefix in constraint", version: "1.7.13", constraint: ">=v1.7.0", want: true},
+		{name: "pre-release before release", version: "1.2.0-rc.1", constraint: ">=1.2.0", want: false},
+		{name: "pre-release after previous", version: "v1.2.0-rc.1", constraint: ">1.1.9", want: true},
+		{name: "pre-release matches wildcard", version: "1.2.0-rc.1", constraint: "1.2.x", want: true},
+		{name: "unknown fails upper bound", version: "unknown", constraint: "<2", want: false},
+		{name: "unknown fails wildcard", version: "unknown", constraint: "1.x", want: false},
+		{name: "unknown with no constraint", version: "unknown", constraint: "", want: true},
+		{name: "malformed operand", version: "unknown", constraint: ">=abc", wantErr: true},
+		{name: "malformed wildcard", version: "1.7.13", constraint: "1.*.x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := satisfiesVersion(tt.version, tt.constraint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("satisfiesVersion(%q, %q) error = %v, wantErr %v", tt.version, tt.constraint, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("satisfiesVersion(%q, %q) = %v, want %v", tt.version, tt.constraint, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CachingDetector wraps a Detector and reuses its last Result for a TTL, so
+// long-running daemons can call Detect per request without re-running every
+// runtime binary and re-dialing sockets. Installed runtimes rarely change;
+// call Invalidate after a known change to force the next Detect to refresh.
+//
+// Errors and empty results (no runtimes) are never cached, so a transient
+// socket failure is retried on the next call. CachingDetector is safe for
+// concurrent use; concurrent callers that find the cache stale wait for a
+// single refresh instead of detecting in parallel.
+type CachingDetector struct {
+	detector *Detector
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	result  *Result
+	expires time.Time
+	refresh *cachingRefresh // In-flight refresh, if any
+}
+
+// cachingRefresh is a Detect call shared by the callers that found the cache
+// stale while it ran. done is closed once result, err, and ctxErr are set.
+type cachingRefresh struct {
+	done   chan struct{}
+	result *Result
+	err    error
+	ctxErr error // The refreshing caller's context error, if it ended mid-refresh
+}
+
+// NewCachingDetector creates a CachingDetector that caches d's results for ttl.
+func NewCachingDetector(d *Detector, ttl time.Duration) *CachingDetector {
+	return &CachingDetector{
+		detector: d,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Detect returns the cached Result if it is younger than the TTL, and
+// otherwise runs the wrapped Detector's Detect. The context applies to that
+// refresh, so its deadline still bounds detection; a cache hit ignores it.
+// Callers arriving during a refresh wait for it and share its outcome, but
+// return ctx.Err() if their own context ends first. If the refresh fails
+// because the refreshing caller's context ended, waiters whose contexts are
+// still alive refresh again rather than inheriting that error.
+//
+// The returned Result is shared by every caller until the cache refreshes,
+// so it must not be modified.
+func (c *CachingDetector) Detect(ctx context.Context) (*Result, error) {
+	for {
+		c.mu.Lock()
+		if c.result != nil && c.now().Before(c.expires) {
+			result := c.result
+			c.mu.Unlock()
+			return result, nil
+		}
+
+		refresh := c.refresh
+		if refresh == nil {
+			refresh = &cachingRefresh{done: make(chan struct{})}
+			c.refresh = refresh
+			c.mu.Unlock()
+			c.run(ctx, refresh)
+			return refresh.result, refresh.err
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-refresh.done:
+			if refresh.ctxErr != nil && ctx.Err() == nil {
+				continue // Another caller's context ended, not ours
+			}
+			return refresh.result, refresh.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// run performs refresh and caches its result, unless Invalidate was called
+// while it ran.
+func (c *CachingDetector) run(ctx context.Context, refresh *cachingRefresh) {
+	defer close(refresh.done)
+	refresh.result, refresh.err = c.detector.Detect(ctx)
+	refresh.ctxErr = ctx.Err()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refresh != refresh {
+		return // Invalidated mid-refresh, so the result may already be stale
+	}
+	c.refresh = nil
+
+	switch {
+	case refresh.err != nil:
+	case refresh.result.IsEmpty():
+		c.result = nil
+	default:
+		c.result = refresh.result
+		c.expires = c.now().Add(c.ttl)
+	}
+}
+
+// Invalidate discards the cached Result, so the next Detect refreshes it.
+// A refresh already running is not cached when it completes.
+func (c *CachingDetector) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.result = nil
+	c.refresh = nil
+}
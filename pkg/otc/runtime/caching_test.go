@@ -0,0 +1,229 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingCRIDetector counts Detect calls and returns its current canned results.
+type countingCRIDetector struct {
+	mu       sync.Mutex
+	calls    atomic.Int32
+	runtimes []Runtime
+	err      error
+}
+
+func (c *countingCRIDetector) Detect(_ context.Context) ([]Runtime, error) {
+	c.calls.Add(1)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Runtime(nil), c.runtimes...), c.err
+}
+
+func (c *countingCRIDetector) set(runtimes []Runtime, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.runtimes, c.err = runtimes, err
+}
+
+func TestCachingDetector_Detect(t *testing.T) {
+	t.Parallel()
+
+	containerd := []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}
+
+	tests := []struct {
+		name      string
+		runtimes  []Runtime
+		err       error
+		advance   time.Duration // Clock advance between the two calls
+		invalid   bool          // Invalidate between the two calls
+		wantCalls int32
+	}{
+		{name: "cached within TTL", runtimes: containerd, advance: 30 * time.Second, wantCalls: 1},
+		{name: "refreshed after TTL", runtimes: containerd, advance: 2 * time.Minute, wantCalls: 2},
+		{name: "refreshed after Invalidate", runtimes: containerd, invalid: true, wantCalls: 2},
+		{name: "empty result not cached", runtimes: nil, wantCalls: 2},
+		{name: "error not cached", err: errors.New("socket not found"), wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cri := &countingCRIDetector{runtimes: tt.runtimes, err: tt.err}
+			detector := NewDetector(nil, cri, nil, WithEmptyResults())
+			detector.override = ""
+
+			now := time.Unix(1700000000, 0)
+			cache := NewCachingDetector(detector, time.Minute)
+			cache.now = func() time.Time { return now }
+
+			_, _ = cache.Detect(context.Background())
+			now = now.Add(tt.advance)
+			if tt.invalid {
+				cache.Invalidate()
+			}
+			_, _ = cache.Detect(context.Background())
+
+			if got := cri.calls.Load(); got != tt.wantCalls {
+				t.Errorf("detector calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCachingDetector_TransientFailure(t *testing.T) {
+	t.Parallel()
+
+	cri := &countingCRIDetector{err: errors.New("connection refused")}
+	detector := NewDetector(nil, cri, nil)
+	detector.override = ""
+	cache := NewCachingDetector(detector, time.Hour)
+
+	if _, err := cache.Detect(context.Background()); err == nil {
+		t.Fatal("Detect() error = nil, want socket failure")
+	}
+
+	cri.set([]Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}, nil)
+	result, err := cache.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() after recovery error = %v", err)
+	}
+	if result.Selected == nil || result.Selected.Name != Containerd {
+		t.Errorf("Selected = %v, want containerd", result.Selected)
+	}
+}
+
+func TestCachingDetector_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	cri := &countingCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}}
+	detector := NewDetector(nil, cri, nil)
+	detector.override = ""
+	cache := NewCachingDetector(detector, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.Detect(context.Background()); err != nil {
+				t.Errorf("Detect() error = %v", err)
+			}
+			if i%5 == 0 {
+				cache.Invalidate()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := cri.calls.Load(); got < 1 || got > 5 {
+		t.Errorf("detector calls = %d, want between 1 and 5", got)
+	}
+}
+
+// blockingCRIDetector signals started on each Detect call and then blocks
+// until release is closed.
+type blockingCRIDetector struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingCRIDetector) Detect(_ context.Context) ([]Runtime, error) {
+	b.started <- struct{}{}
+	<-b.release
+	return []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}, nil
+}
+
+func TestCachingDetector_WaiterContext(t *testing.T) {
+	t.Parallel()
+
+	cri := &blockingCRIDetector{started: make(chan struct{}, 1), release: make(chan struct{})}
+	detector := NewDetector(nil, cri, nil, WithoutHostChecks())
+	detector.override = ""
+	cache := NewCachingDetector(detector, time.Hour)
+
+	refreshed := make(chan error, 1)
+	go func() {
+		_, err := cache.Detect(context.Background())
+		refreshed <- err
+	}()
+	<-cri.started
+
+	// A waiter gives up when its own context ends, without waiting for the refresh
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cache.Detect(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("waiting Detect() error = %v, want %v", err, context.Canceled)
+	}
+
+	close(cri.release)
+	if err := <-refreshed; err != nil {
+		t.Fatalf("refreshing Detect() error = %v", err)
+	}
+	result, err := cache.Detect(context.Background())
+	if err != nil || result.Selected == nil || result.Selected.Name != Containerd {
+		t.Errorf("cached Detect() = %v, %v, want containerd", result, err)
+	}
+}
+
+// ctxBlockingCRIDetector signals started on each Detect call and then blocks
+// until its context ends or release is closed.
+type ctxBlockingCRIDetector struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *ctxBlockingCRIDetector) Detect(ctx context.Context) ([]Runtime, error) {
+	b.started <- struct{}{}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-b.release:
+		return []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}, nil
+	}
+}
+
+func TestCachingDetector_LeaderCanceled(t *testing.T) {
+	t.Parallel()
+
+	cri := &ctxBlockingCRIDetector{started: make(chan struct{}, 2), release: make(chan struct{})}
+	detector := NewDetector(nil, cri, nil, WithoutHostChecks())
+	detector.override = ""
+	cache := NewCachingDetector(detector, time.Hour)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := cache.Detect(leaderCtx)
+		leaderErr <- err
+	}()
+	<-cri.started
+
+	waiter := make(chan *Result, 1)
+	go func() {
+		result, err := cache.Detect(context.Background())
+		if err != nil {
+			t.Errorf("waiting Detect() error = %v", err)
+		}
+		waiter <- result
+	}()
+
+	// The leader gives up; the waiter's context is alive, so it refreshes itself
+	time.Sleep(10 * time.Millisecond) // Let the waiter start waiting
+	cancelLeader()
+	if err := <-leaderErr; err == nil {
+		t.Error("leader Detect() error = nil, want its cancellation")
+	}
+
+	<-cri.started
+	close(cri.release)
+	if result := <-waiter; result == nil || result.Selected == nil || result.Selected.Name != Containerd {
+		t.Errorf("waiting Detect() = %v, want containerd", result)
+	}
+}
@@ -0,0 +1,77 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// DetectCandidates probes exactly the given OCI runtime binaries and CRI sockets,
+// without consulting PATH, the default socket locations, or OTC_RUNTIME.
+// Binaries are executed by path and named after their base name; sockets are
+// queried as containerd over CRI with default settings, ignoring OTC_DETECT_TIMEOUT.
+//
+// Candidates that fail to respond are reported in Result.Warnings.
+// Returns error only if no candidate could be detected and at least one failed.
+// Host-level checks (systemd, kubelet) are not run, keeping detection hermetic.
+func DetectCandidates(ctx context.Context, binaries []string, sockets []string) (*Result, error) {
+	return (&Detector{}).DetectCandidates(ctx, binaries, sockets)
+}
+
+// DetectCandidates is the package-level DetectCandidates, but probes through
+// d's built-in OCI and containerd detectors, so their command runner, timeout,
+// namespace, SSH dialer, and config path apply. Detectors d doesn't have are
+// replaced by ones with default settings that read no environment variables.
+func (d *Detector) DetectCandidates(ctx context.Context, binaries []string, sockets []string) (*Result, error) {
+	start := time.Now()
+	var runtimes []Runtime
+	var warnings []error
+
+	oci, ok := d.oci.(*ociDetector)
+	if !ok {
+		oci = &ociDetector{}
+	}
+	for _, path := range binaries {
+		runtime, err := oci.probeBinary(ctx, filepath.Base(path), path)
+		if err != nil {
+			warnings = append(warnings, fmt.Errorf("candidate %s: %w", path, err))
+			continue
+		}
+		runtimes = append(runtimes, runtime)
+	}
+
+	cri := d.containerdDetector()
+	if cri == nil {
+		cri = candidateContainerdDetector()
+	}
+	for _, socket := range sockets {
+		runtime, err := cri.detectAt(ctx, socket)
+		if err != nil {
+			warnings = append(warnings, fmt.Errorf("candidate %s: %w", socket, err))
+			continue
+		}
+		runtimes = append(runtimes, runtime)
+	}
+
+	if len(runtimes) == 0 && len(warnings) > 0 {
+		return nil, warnings[0]
+	}
+
+	result := NewResult(runtimes, warnings)
+	annotateInterchangeable(result.Runtimes)
+	result.stamp(start)
+	return result, nil
+}
+
+// candidateContainerdDetector returns a containerd detector with the default
+// timeout, user agent, and namespace, without reading OTC_DETECT_TIMEOUT or
+// OTC_CONTAINERD_SOCKET. No system containerd config path is set.
+func candidateContainerdDetector() *ContainerdDetector {
+	return &ContainerdDetector{
+		timeout:   defaultDetectTimeout,
+		userAgent: defaultUserAgent,
+		namespace: defaultCRINamespace,
+		procRoot:  "/proc",
+	}
+}
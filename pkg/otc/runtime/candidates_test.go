@@ -0,0 +1,99 @@
+package runtime
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectCandidates(t *testing.T) {
+	t.Parallel()
+
+	binDir := t.TempDir()
+	runcPath := writeFakeBinary(t, binDir, Runc, `echo "runc version 1.1.12"`)
+	socketPath := startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.0"}, nil)
+
+	tests := []struct {
+		name         string
+		binaries     []string
+		sockets      []string
+		wantNames    []string
+		wantWarnings int
+		wantErr      bool
+	}{
+		{
+			name:      "binary and socket",
+			binaries:  []string{runcPath},
+			sockets:   []string{socketPath},
+			wantNames: []string{Containerd, Runc},
+		},
+		{
+			name:         "missing candidate becomes warning",
+			binaries:     []string{runcPath, filepath.Join(binDir, "crun")},
+			wantNames:    []string{Runc},
+			wantWarnings: 1,
+		},
+		{
+			name:     "no candidate detected",
+			binaries: []string{filepath.Join(binDir, "youki")},
+			wantErr:  true,
+		},
+		{
+			name:      "no candidates",
+			wantNames: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := DetectCandidates(context.Background(), tt.binaries, tt.sockets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DetectCandidates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var names []string
+			for _, rt := range result.Runtimes {
+				names = append(names, rt.Name)
+			}
+			if len(names) != len(tt.wantNames) {
+				t.Fatalf("runtimes = %v, want %v", names, tt.wantNames)
+			}
+			for i := range names {
+				if names[i] != tt.wantNames[i] {
+					t.Fatalf("runtimes = %v, want %v", names, tt.wantNames)
+				}
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %v, want %d", result.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestDetector_DetectCandidates_UsesDetectors(t *testing.T) {
+	t.Parallel()
+
+	const raw = "ssh://core@node1/run/containerd/containerd.sock"
+	socketPath := startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.0"}, nil)
+	ssh := &fakeSSHDialer{local: socketPath, calls: make(chan endpoint, 8)}
+
+	// The package-level function has no SSH dialer
+	if _, err := DetectCandidates(context.Background(), nil, []string{raw}); err == nil {
+		t.Error("DetectCandidates() error = nil, want missing SSH dialer")
+	}
+
+	detector := NewDetector(nil, NewContainerdDetector(WithSSHDialer(ssh)), nil)
+	result, err := detector.DetectCandidates(context.Background(), nil, []string{raw})
+	if err != nil {
+		t.Fatalf("Detector.DetectCandidates() error = %v", err)
+	}
+	if result.Selected == nil || result.Selected.Name != Containerd || result.Selected.Path != raw {
+		t.Errorf("Selected = %v, want containerd at %s", result.Selected, raw)
+	}
+}
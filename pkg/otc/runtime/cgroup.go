@@ -0,0 +1,32 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// defaultCgroupRoot is where the cgroup filesystem is mounted
+const defaultCgroupRoot = "/sys/fs/cgroup"
+
+// DetectCgroupVersion reports which cgroup version the host runs, which
+// decides how container runtimes apply resource limits: 2 for the unified
+// hierarchy (/sys/fs/cgroup/cgroup.controllers exists), or 1 for the legacy
+// per-controller hierarchy (/sys/fs/cgroup/memory exists). Hybrid hosts, which
+// mount the unified hierarchy below /sys/fs/cgroup/unified, report 1 since
+// runtimes place controllers on the legacy hierarchy there.
+// Returns error if neither layout is found, e.g. cgroups are not mounted.
+func DetectCgroupVersion() (int, error) {
+	return cgroupVersion(defaultCgroupRoot)
+}
+
+// cgroupVersion reports the cgroup version of the hierarchy mounted at root.
+func cgroupVersion(root string) (int, error) {
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+		return 2, nil
+	}
+	if _, err := os.Stat(filepath.Join(root, "memory")); err == nil {
+		return 1, nil
+	}
+	return 0, fmt.Errorf("cgroup version unknown: no cgroup.controllers or memory controller under %s", root)
+}
@@ -0,0 +1,90 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCgroupVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		files   []string
+		dirs    []string
+		want    int
+		wantErr bool
+	}{
+		{name: "unified", files: []string{"cgroup.controllers"}, dirs: []string{"system.slice"}, want: 2},
+		{name: "legacy", dirs: []string{"memory", "cpu"}, want: 1},
+		{name: "hybrid", dirs: []string{"memory", "unified"}, files: []string{"unified/cgroup.controllers"}, want: 1},
+		{name: "not mounted", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			for _, dir := range tt.dirs {
+				if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+					t.Fatalf("failed to create %s: %v", dir, err)
+				}
+			}
+			for _, file := range tt.files {
+				if err := os.WriteFile(filepath.Join(root, file), []byte("cpu memory pids\n"), 0644); err != nil {
+					t.Fatalf("failed to write %s: %v", file, err)
+				}
+			}
+
+			got, err := cgroupVersion(root)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cgroupVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("cgroupVersion() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectCgroupVersion(t *testing.T) {
+	t.Parallel()
+
+	// The host's cgroup setup varies, so only check the result is consistent
+	version, err := DetectCgroupVersion()
+	if err != nil {
+		t.Logf("DetectCgroupVersion() error = %v (cgroups not mounted)", err)
+		return
+	}
+	if version != 1 && version != 2 {
+		t.Errorf("DetectCgroupVersion() = %d, want 1 or 2", version)
+	}
+}
+
+func TestOCIDetector_CgroupVersion(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	writeFakeBinary(t, binDir, Runc, `echo "runc version 1.1.12"`)
+	t.Setenv("PATH", binDir)
+
+	cgroupRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(cgroupRoot, "cgroup.controllers"), []byte("cpu memory\n"), 0644); err != nil {
+		t.Fatalf("failed to write cgroup.controllers: %v", err)
+	}
+
+	detector := NewOCIDetectorWithNames(Runc).(*ociDetector)
+	detector.cgroupRoot = cgroupRoot
+
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(runtimes) != 1 || runtimes[0].CgroupVersion != 2 {
+		t.Errorf("Detect() = %+v, want runc with CgroupVersion 2", runtimes)
+	}
+}
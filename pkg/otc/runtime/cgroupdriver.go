@@ -0,0 +1,56 @@
+package runtime
+
+// criCgroupDriver derives the cgroup driver from a CRI config: "systemd" when the
+// default runtime handler enables SystemdCgroup, otherwise "cgroupfs".
+// Returns "" when the config doesn't describe the default handler.
+func criCgroupDriver(cfg criConfig) string {
+	handler := cfg.str("containerd", "defaultRuntimeName")
+	if handler == "" {
+		return ""
+	}
+
+	value, ok := cfg.lookup("containerd", "runtimes", handler, "options", "SystemdCgroup")
+	if !ok {
+		// Legacy top-level setting from containerd 1.3 and earlier
+		value, ok = cfg.lookup("systemdCgroup")
+	}
+	if enabled, _ := value.(bool); ok && enabled {
+		return "systemd"
+	}
+	return "cgroupfs"
+}
+
+// kubeletCgroupDriver reads cgroupDriver from the kubelet config file.
+// Kubelet defaults to cgroupfs when the field is unset.
+// Returns "" if the config file can't be read.
+func (p kubernetesProbe) kubeletCgroupDriver() string {
+	config, ok := p.kubeletConfig()
+	if !ok {
+		return ""
+	}
+	if driver := config["cgroupDriver"]; driver != "" {
+		return driver
+	}
+	return "cgroupfs"
+}
+
+// checkCgroupDriver warns, with high severity, when a CRI runtime and the kubelet
+// use different cgroup drivers, a common cause of node failures.
+// Skipped when either driver can't be determined.
+func checkCgroupDriver(runtimes []Runtime, k8s kubernetesProbe) []error {
+	kubelet := k8s.kubeletCgroupDriver()
+	if kubelet == "" {
+		return nil
+	}
+
+	var warnings []error
+	for _, rt := range runtimes {
+		driver := rt.Capabilities["cgroupDriver"]
+		if rt.Type != TypeCRI || driver == "" || driver == kubelet {
+			continue
+		}
+		warnings = append(warnings, newWarning(SeverityHigh,
+			"%s uses the %s cgroup driver but kubelet uses %s", rt.Name, driver, kubelet))
+	}
+	return warnings
+}
@@ -0,0 +1,127 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCriCgroupDriver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{
+			name:   "systemd cgroup",
+			config: `{"containerd": {"defaultRuntimeName": "runc", "runtimes": {"runc": {"options": {"SystemdCgroup": true}}}}}`,
+			want:   "systemd",
+		},
+		{
+			name:   "cgroupfs",
+			config: `{"containerd": {"defaultRuntimeName": "runc", "runtimes": {"runc": {"options": {"SystemdCgroup": false}}}}}`,
+			want:   "cgroupfs",
+		},
+		{
+			name:   "legacy top-level setting",
+			config: `{"systemdCgroup": true, "containerd": {"defaultRuntimeName": "runc", "runtimes": {"runc": {}}}}`,
+			want:   "systemd",
+		},
+		{
+			name:   "no default handler",
+			config: `{"containerd": {}}`,
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := parseCRIConfig(map[string]string{"config": tt.config})
+			if err != nil {
+				t.Fatalf("parseCRIConfig() error = %v", err)
+			}
+			if got := criCgroupDriver(cfg); got != tt.want {
+				t.Errorf("criCgroupDriver() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCgroupDriver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		kubeletConfig string
+		runtimeDriver string
+		wantWarns     int
+	}{
+		{
+			name:          "mismatch",
+			kubeletConfig: "apiVersion: kubelet.config.k8s.io/v1beta1\nkind: KubeletConfiguration\ncgroupDriver: systemd\n",
+			runtimeDriver: "cgroupfs",
+			wantWarns:     1,
+		},
+		{
+			name:          "kubelet default cgroupfs mismatch",
+			kubeletConfig: "kind: KubeletConfiguration\n",
+			runtimeDriver: "systemd",
+			wantWarns:     1,
+		},
+		{
+			name:          "match",
+			kubeletConfig: "cgroupDriver: \"systemd\"\n",
+			runtimeDriver: "systemd",
+			wantWarns:     0,
+		},
+		{
+			name:          "no kubelet",
+			kubeletConfig: "",
+			runtimeDriver: "cgroupfs",
+			wantWarns:     0,
+		},
+		{
+			name:          "runtime driver unknown",
+			kubeletConfig: "cgroupDriver: systemd\n",
+			runtimeDriver: "",
+			wantWarns:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			if tt.kubeletConfig != "" {
+				dir := filepath.Join(root, "var", "lib", "kubelet")
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					t.Fatalf("failed to create kubelet dir: %v", err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.kubeletConfig), 0644); err != nil {
+					t.Fatalf("failed to write kubelet config: %v", err)
+				}
+			}
+
+			caps := map[string]string{}
+			if tt.runtimeDriver != "" {
+				caps["cgroupDriver"] = tt.runtimeDriver
+			}
+			runtimes := []Runtime{{Name: Containerd, Type: TypeCRI, Capabilities: caps}}
+
+			warnings := checkCgroupDriver(runtimes, kubernetesProbe{root: root})
+			if len(warnings) != tt.wantWarns {
+				t.Fatalf("expected %d warnings, got %d: %v", tt.wantWarns, len(warnings), warnings)
+			}
+			if tt.wantWarns > 0 && WarningSeverity(warnings[0]) != SeverityHigh {
+				t.Errorf("warning severity = %v, want %v", WarningSeverity(warnings[0]), SeverityHigh)
+			}
+		})
+	}
+}
@@ -2,113 +2,696 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/mango-habanero/otc/pkg/otc"
+)
+
+// defaultCRINamespace is the containerd namespace Kubernetes uses
+const defaultCRINamespace = "k8s.io"
+
+// containerdNamespaceHeader is the gRPC metadata key containerd reads the namespace from
+const containerdNamespaceHeader = "containerd-namespace"
+
+// defaultUserAgent identifies detection connections in runtime logs and metrics
+const defaultUserAgent = "otc/" + otc.Version
+
+// defaultContainerdConfig is the standard containerd configuration file location
+const defaultContainerdConfig = "/etc/containerd/config.toml"
+
+// k3sContainerdSocket and k3sContainerdConfig are where K3s and RKE2 run their
+// embedded containerd; its config is generated there, not in /etc/containerd
+const (
+	k3sContainerdSocket = "/run/k3s/containerd/containerd.sock"
+	k3sContainerdConfig = "/var/lib/rancher/k3s/agent/etc/containerd/config.toml"
 )
 
 // Standard containerd socket paths in order of preference
 var containerdSocketPaths = []string{
 	"/run/containerd/containerd.sock",     // Primary - canonical location
 	"/var/run/containerd/containerd.sock", // Alternative - symlink on modern systems
-	"/run/k3s/containerd/containerd.sock", // K3s/RKE2
+	k3sContainerdSocket,                   // K3s/RKE2
+}
+
+// rootlessContainerdSocket returns the socket path of a rootless containerd
+// (nerdctl, Rancher Desktop) for the current user: under $XDG_RUNTIME_DIR,
+// or /run/user/<uid> when it is unset.
+func rootlessContainerdSocket() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = filepath.Join("/run/user", strconv.Itoa(os.Getuid()))
+	}
+	return filepath.Join(dir, "containerd", "containerd.sock")
+}
+
+// rootlessContainerdConfig returns the config file of a rootless containerd
+// for the current user: under $XDG_CONFIG_HOME, or ~/.config when it is unset.
+// Returns "" if neither is known.
+func rootlessContainerdConfig() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "containerd", "config.toml")
+}
+
+// SocketPrecedence controls which socket wins when several candidates exist.
+type SocketPrecedence int
+
+const (
+	// SystemFirst tries system-wide sockets before rootless ones (default)
+	SystemFirst SocketPrecedence = iota
+
+	// RootlessFirst tries rootless sockets under the user's runtime directory first
+	RootlessFirst
+)
+
+// SocketOrderFunc returns the candidate socket paths in the order they should be tried.
+// It must not modify the input slice.
+type SocketOrderFunc func(paths []string) []string
+
+// ContainerdOption configures a ContainerdDetector.
+type ContainerdOption func(*ContainerdDetector)
+
+// WithSocketPrecedence sets the built-in ordering used when searching for sockets.
+func WithSocketPrecedence(p SocketPrecedence) ContainerdOption {
+	return func(d *ContainerdDetector) {
+		d.precedence = p
+		d.order = nil
+	}
+}
+
+// WithSocketOrder sets a custom ordering function for socket paths.
+// It takes precedence over any SocketPrecedence setting.
+func WithSocketOrder(fn SocketOrderFunc) ContainerdOption {
+	return func(d *ContainerdDetector) {
+		d.order = fn
+	}
+}
+
+// WithUserAgent sets the gRPC user-agent sent on CRI connections.
+// Defaults to "otc/<Version>".
+func WithUserAgent(userAgent string) ContainerdOption {
+	return func(d *ContainerdDetector) {
+		d.userAgent = userAgent
+	}
+}
+
+// WithEndpoint makes the detector query only the given socket endpoint instead
+// of searching the default socket paths. The endpoint may be a socket path,
+// a unix:// URL, or an ssh://[user@]host[:port]/path URL, which requires WithSSHDialer.
+// Host-local probes (NVIDIA, OOM score, crictl fallback) are skipped for ssh:// endpoints.
+func WithEndpoint(endpoint string) ContainerdOption {
+	return func(d *ContainerdDetector) {
+		d.endpoint = endpoint
+	}
+}
+
+// WithSSHDialer sets the dialer used to reach ssh:// endpoints.
+func WithSSHDialer(dialer SSHDialer) ContainerdOption {
+	return func(d *ContainerdDetector) {
+		d.ssh = dialer
+	}
+}
+
+// WithNamespace sets the containerd namespace sent as the "containerd-namespace"
+// gRPC metadata header on CRI calls. Defaults to "k8s.io", the namespace
+// Kubernetes uses; e.g. nerdctl uses "default". An empty namespace sends no
+// header, leaving the choice to containerd.
+func WithNamespace(namespace string) ContainerdOption {
+	return func(d *ContainerdDetector) {
+		d.namespace = namespace
+	}
+}
+
+// WithConfigPath sets the containerd config file read for host-local settings,
+// such as the default runtime handler, of a system containerd. Defaults to
+// /etc/containerd/config.toml. Rootless, K3s, and Lima daemons don't use it.
+func WithConfigPath(path string) ContainerdOption {
+	return func(d *ContainerdDetector) {
+		d.configPath = path
+	}
+}
+
+// WithTimeout sets the timeout applied to each CRI call.
+// It overrides both the default and OTC_DETECT_TIMEOUT.
+func WithTimeout(timeout time.Duration) ContainerdOption {
+	return func(d *ContainerdDetector) {
+		d.timeout = timeout
+		d.timeoutWarning = nil
+	}
 }
 
 // ContainerdDetector detects containerd via CRI socket
 type ContainerdDetector struct {
+	endpoint    string    // If set, the only socket endpoint queried
+	ssh         SSHDialer // Dialer for ssh:// endpoints
 	socketPaths []string
 	timeout     time.Duration
+	// timeoutWarning reports an invalid OTC_DETECT_TIMEOUT with each detection
+	timeoutWarning error
+	precedence     SocketPrecedence
+	order          SocketOrderFunc
+	configPath     string
+	nvidia         nvidiaProbe
+	userAgent      string
+	namespace      string   // containerd namespace for CRI calls; empty sends none
+	retry          criRetry // Retries of the Version call; zero makes one attempt
+	procRoot       string
+	negative       *negativeCache // Socket paths recently found missing
+	run            commandRunner  // Runs probe commands; nil means execOutput
+	logger         *slog.Logger   // Debug tracing; nil logs nothing
 }
 
-// NewContainerdDetector creates a new containerd detector with default settings
-func NewContainerdDetector() *ContainerdDetector {
-	return &ContainerdDetector{
-		socketPaths: containerdSocketPaths,
-		timeout:     5 * time.Second, // Default timeout for CRI calls
+// NewContainerdDetector creates a new containerd detector with default settings.
+// It searches the standard rootful sockets, then the current user's rootless
+// socket under $XDG_RUNTIME_DIR (see WithSocketPrecedence to prefer it), then
+// the sockets Lima forwards from its VMs (~/.lima/<instance>/sock/containerd.sock).
+// A socket named by OTC_CONTAINERD_SOCKET is tried ahead of all of them.
+// The per-call CRI timeout is read from OTC_DETECT_TIMEOUT when set; an invalid
+// value falls back to the default and is reported as a warning by Detect.
+func NewContainerdDetector(opts ...ContainerdOption) *ContainerdDetector {
+	timeout, timeoutErr := getTimeoutFromEnv(defaultDetectTimeout)
+	d := &ContainerdDetector{
+		socketPaths:    append(append(append([]string(nil), containerdSocketPaths...), rootlessContainerdSocket()), limaContainerdSockets()...),
+		timeout:        timeout,
+		timeoutWarning: timeoutErr,
+		precedence:     SystemFirst,
+		configPath:     defaultContainerdConfig,
+		userAgent:      defaultUserAgent,
+		namespace:      defaultCRINamespace,
+		procRoot:       "/proc",
+		negative:       newNegativeCache(defaultNegativeTTL),
+		nvidia: nvidiaProbe{
+			toolkitConfig: defaultNVIDIAToolkitConfig,
+		},
+	}
+	for _, opt := range opts {
+		opt(d)
 	}
+	return d
+}
+
+// setTimeout sets the per-call CRI timeout, like WithTimeout.
+func (d *ContainerdDetector) setTimeout(timeout time.Duration) {
+	WithTimeout(timeout)(d)
+}
+
+// setNamespace sets the containerd namespace for CRI calls, like WithNamespace.
+func (d *ContainerdDetector) setNamespace(namespace string) {
+	WithNamespace(namespace)(d)
+}
+
+// setRetry sets how the Version call is retried, for WithCRIRetry.
+func (d *ContainerdDetector) setRetry(attempts int, backoff time.Duration) {
+	d.retry = criRetry{attempts: attempts, backoff: backoff}
+}
+
+// setLogger sets the logger used to trace socket discovery.
+func (d *ContainerdDetector) setLogger(logger *slog.Logger) {
+	d.logger = logger
+}
+
+// appendSocketPaths adds socket paths to search after the configured ones.
+func (d *ContainerdDetector) appendSocketPaths(paths []string) {
+	d.socketPaths = append(append([]string(nil), d.socketPaths...), paths...)
 }
 
 // Detect attempts to detect containerd via CRI socket
 func (d *ContainerdDetector) Detect(ctx context.Context) ([]Runtime, error) {
-	// Find first accessible socket
-	socket, err := d.findSocket()
+	// Use the configured endpoint, or find the first accessible socket
+	socket := d.endpoint
+	var warnings []error
+	if socket == "" {
+		var err error
+		socket, warnings, err = d.findSocket()
+		if errors.Is(err, ErrSocketNotAccessible) {
+			return nil, fmt.Errorf("containerd %w", err)
+		}
+		if err != nil {
+			return d.detectNerdctl(ctx, err, warnings)
+		}
+	}
+
+	runtime, err := d.detectAt(ctx, socket)
 	if err != nil {
-		return nil, fmt.Errorf("containerd socket not found: %w", err)
+		return nil, err
+	}
+	if d.timeoutWarning != nil {
+		warnings = append(warnings, d.timeoutWarning)
 	}
+	return []Runtime{runtime}, joinWarnings(warnings)
+}
+
+// detectNerdctl reports containerd through nerdctl when no socket is reachable
+// on the host, as on Lima and Rancher Desktop where containerd runs in a VM.
+// socketErr and warnings are from findSocket: both are reported alongside
+// nerdctl's error if it fails too, and the warnings are kept if it succeeds.
+func (d *ContainerdDetector) detectNerdctl(ctx context.Context, socketErr error, warnings []error) ([]Runtime, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
 
-	// Get version via CRI API
-	version, err := d.getVersion(ctx, socket)
+	path, version, err := nerdctlContainerdVersion(ctx, d.runner())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get containerd version from CRI: %w", err)
+		return nil, fmt.Errorf("containerd socket %w: %w", ErrRuntimeNotFound,
+			errors.Join(append(append([]error{socketErr}, warnings...), err)...))
 	}
 
-	return []Runtime{
-		{
-			Name:     Containerd,
-			Type:     TypeCRI,
-			Version:  version,
-			Path:     socket,
-			Priority: PriorityCRI,
-		},
+	runtime := Runtime{
+		Name:         Containerd,
+		Type:         TypeCRI,
+		Version:      version,
+		Path:         path,
+		Priority:     PriorityCRI,
+		Capabilities: map[string]string{"client": Nerdctl},
+	}
+	if d.timeoutWarning != nil {
+		warnings = append(warnings, d.timeoutWarning)
+	}
+	return []Runtime{runtime}, joinWarnings(warnings)
+}
+
+// detectAt queries containerd over the CRI socket at the given endpoint,
+// which is a socket path or URL accepted by parseEndpoint.
+func (d *ContainerdDetector) detectAt(ctx context.Context, socket string) (Runtime, error) {
+	ep, err := parseEndpoint(socket)
+	if err != nil {
+		return Runtime{}, err
+	}
+
+	// Establish gRPC connection to containerd socket, shared by all CRI calls
+	conn, err := d.dialEndpoint(ep)
+	if err != nil {
+		return Runtime{}, fmt.Errorf("failed to get containerd version from CRI: %w", err)
+	}
+	defer closeConn(conn)
+
+	// Get version via CRI API, falling back to a configured crictl on this host
+	resp, err := d.getVersion(ctx, conn)
+	version, apiVersion := resp.GetRuntimeVersion(), resp.GetRuntimeApiVersion()
+	loggerOrDiscard(d.logger).Debug("CRI version queried", "runtime", Containerd, "endpoint", socket, "version", version, "error", err)
+	if err == nil {
+		// A mismatch is a definite answer, so crictl is not consulted
+		if mismatch := checkCRIRuntimeName(Containerd, socket, resp.GetRuntimeName()); mismatch != nil {
+			return Runtime{}, mismatch
+		}
+	}
+	if err != nil {
+		if ep.remote() {
+			return Runtime{}, fmt.Errorf("failed to get containerd version from CRI: %w", err)
+		}
+		// Tear down the unanswered connection now rather than holding its
+		// reconnect goroutines open for the duration of the crictl fallback.
+		// The CRI probes below are skipped, as nothing is left to answer them.
+		closeConn(conn)
+		conn = nil
+		fallback, fallbackErr := crictlVersion(ctx, d.runner(), Containerd, "unix://"+ep.path)
+		if fallbackErr != nil {
+			return Runtime{}, fmt.Errorf("failed to get containerd version from CRI: %w", err)
+		}
+		version = fallback
+	}
+
+	var configPath string
+	if !ep.remote() {
+		configPath = d.endpointConfigPath(ep.path)
+	}
+	caps, handlers := d.probeCapabilities(ctx, conn, ep.remote(), ep.path, configPath)
+	var socketInfo *SocketInfo
+	var defaultHandler string
+	if !ep.remote() {
+		socketInfo = localSocketInfo(ep.path)
+		defaultHandler = containerdDefaultHandler(configPath)
+	}
+	return Runtime{
+		Name:           Containerd,
+		Type:           TypeCRI,
+		Version:        version,
+		Path:           socket,
+		Priority:       PriorityCRI,
+		Rootless:       !ep.remote() && isRootlessSocket(ep.path),
+		APIVersion:     apiVersion,
+		SpecVersion:    containerdSpecVersion(version),
+		Handlers:       handlers,
+		DefaultHandler: defaultHandler,
+		Socket:         socketInfo,
+		Capabilities:   caps,
 	}, nil
 }
 
-// findSocket searches for the first accessible containerd socket
-func (d *ContainerdDetector) findSocket() (string, error) {
-	for _, path := range d.socketPaths {
+// probeCapabilities runs the best-effort CRI, host, and config probes for containerd.
+// Host probes describe this machine, so they are skipped for remote daemons.
+// Config probes read configPath, the daemon's own config (see endpointConfigPath),
+// and socketPath identifies the daemon's process among several containerds.
+// The runtime handler names from the CRI status are returned alongside.
+// A nil conn, as after the crictl fallback, skips the CRI probes.
+func (d *ContainerdDetector) probeCapabilities(ctx context.Context, conn *grpc.ClientConn, remote bool, socketPath, configPath string) (map[string]string, []string) {
+	caps := make(map[string]string)
+	if conn != nil {
+		caps["imageServiceReady"] = strconv.FormatBool(d.imageServiceReady(ctx, conn) == nil)
+
+		if supported, ok := d.checkpointSupported(ctx, conn); ok {
+			caps["criCheckpoint"] = strconv.FormatBool(supported)
+		}
+	}
+
+	if !remote {
+		caps["nvidiaReady"] = strconv.FormatBool(d.nvidia.ready(configPath))
+
+		if adj := daemonOOMScoreAdj(d.procRoot, Containerd, socketPath, configPath); adj != "" {
+			caps["oomScoreAdj"] = adj
+		}
+
+		caps = mergeCapabilities(caps, daemonLogSettings(configPath))
+		caps = mergeCapabilities(caps, probeBackingFs(ctx, filepath.Join(d.procRoot, "self", "mounts"),
+			containerdRoot(configPath), d.runner()))
+	}
+
+	// Handlers and settings from the CRI status; skipped if Status is unavailable
+	if conn == nil {
+		return caps, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	status, err := getCRIStatus(ctx, conn)
+	if err != nil {
+		return caps, nil
+	}
+	handlers := runtimeHandlerNames(status.GetRuntimeHandlers())
+
+	if handlers := formatRuntimeHandlers(status.GetRuntimeHandlers()); handlers != "" {
+		caps["handlers"] = handlers
+	}
+
+	if supported, ok := criRROMounts(status.GetRuntimeHandlers(), d.procRoot, remote); ok {
+		caps["rroMounts"] = strconv.FormatBool(supported)
+	}
+
+	if features, ok := formatRuntimeFeatures(status.GetFeatures()); ok {
+		caps["criFeatures"] = features
+	}
+
+	cfg, err := parseCRIConfig(status.GetInfo())
+	if err != nil {
+		return caps, handlers
+	}
+
+	// Reported even when empty, so a missing pause image can be flagged
+	caps["sandboxImage"] = cfg.str("sandboxImage")
+
+	if driver := criCgroupDriver(cfg); driver != "" {
+		caps["cgroupDriver"] = driver
+	}
+
+	if ulimits := cfg.str("defaultUlimits"); ulimits != "" {
+		caps["defaultUlimits"] = ulimits
+	}
+
+	// Binary paths belong to the daemon's host, so only local ones are usable
+	// by checkVersionSkew, which runs them
+	if binaries := criRuntimeBinaries(cfg); binaries != "" && !remote {
+		caps["ociBinaries"] = binaries
+	}
+
+	if profile := criAppArmorProfile(cfg); profile != "" {
+		caps["apparmorProfile"] = profile
+	}
+
+	return caps, handlers
+}
+
+// findSocket searches for the first accessible containerd socket.
+// A socket named by OTC_CONTAINERD_SOCKET is tried before the configured paths.
+// Broken symlinks passed over on the way are returned as warnings.
+func (d *ContainerdDetector) findSocket() (string, []error, error) {
+	paths := withEnvSocket(containerdSocketEnv, d.orderedSocketPaths())
+	log := loggerOrDiscard(d.logger)
+	var denied error
+	var warnings []error
+	for _, path := range paths {
+		if d.negative.absent(path) {
+			logSocketSkipped(log, Containerd, path, skipRecentlyMissing)
+			continue // Recently missing and its directory is unchanged
+		}
+
 		// Check if path exists
 		info, err := os.Stat(path)
 		if err != nil {
+			if broken := brokenSymlinkWarning(path); broken != nil {
+				// Not cached as missing, so the warning repeats until fixed
+				logSocketSkipped(log, Containerd, path, skipBrokenSymlink)
+				warnings = append(warnings, broken)
+			} else if os.IsNotExist(err) {
+				d.negative.record(path, []string{filepath.Dir(path)})
+				logSocketSkipped(log, Containerd, path, skipNotExist)
+			} else {
+				logSocketSkipped(log, Containerd, path, skipStatFailed)
+			}
 			continue // Socket doesn't exist, try next
 		}
 
 		// Verify it's actually a socket
 		if info.Mode()&os.ModeSocket == 0 {
+			logSocketSkipped(log, Containerd, path, skipNotSocket)
 			continue // Not a socket, try next
 		}
 
-		return path, nil
+		// Skip a socket we can't connect to in favor of a later one, but
+		// report the permissions problem if no other socket is found
+		if socket, ok := statSocket(info); ok && !socket.Accessible {
+			logSocketSkipped(log, Containerd, path, skipPermission)
+			if denied == nil {
+				denied = socketNotAccessibleError(path, socket, info.Mode().Perm())
+			}
+			continue
+		}
+
+		log.Debug("socket found", "runtime", Containerd, "path", path)
+		return path, warnings, nil
 	}
 
-	return "", fmt.Errorf("no accessible socket found in: %v", d.socketPaths)
+	if denied != nil {
+		return "", warnings, denied
+	}
+	return "", warnings, fmt.Errorf("no accessible socket found in: %v", paths)
 }
 
-// getVersion connects to containerd via CRI and retrieves version information
-func (d *ContainerdDetector) getVersion(ctx context.Context, socketPath string) (string, error) {
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(ctx, d.timeout)
-	defer cancel()
+// orderedSocketPaths returns the socket paths in the order they should be tried.
+func (d *ContainerdDetector) orderedSocketPaths() []string {
+	if d.order != nil {
+		return d.order(d.socketPaths)
+	}
+
+	if d.precedence != RootlessFirst {
+		return d.socketPaths
+	}
+
+	// Stable partition: rootless sockets first, relative order preserved
+	ordered := make([]string, 0, len(d.socketPaths))
+	for _, path := range d.socketPaths {
+		if isRootlessSocket(path) {
+			ordered = append(ordered, path)
+		}
+	}
+	for _, path := range d.socketPaths {
+		if !isRootlessSocket(path) {
+			ordered = append(ordered, path)
+		}
+	}
+	return ordered
+}
 
-	// Establish gRPC connection to containerd socket using NewClient
-	conn, err := grpc.NewClient(
-		"unix://"+socketPath,
+// isRootlessSocket reports whether a socket path lives in a per-user runtime directory.
+func isRootlessSocket(path string) bool {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+	return strings.HasPrefix(path, "/run/user/")
+}
+
+// endpointConfigPath returns the config file of the containerd listening on the
+// local socket path: the rootless config for rootless sockets, K3s's generated
+// config for its socket, and the configured system config for other sockets.
+// Returns "" for sockets Lima forwards from a VM, whose config isn't on this host.
+func (d *ContainerdDetector) endpointConfigPath(path string) string {
+	switch {
+	case isLimaSocket(path):
+		return ""
+	case isRootlessSocket(path):
+		return rootlessContainerdConfig()
+	case path == k3sContainerdSocket:
+		return k3sContainerdConfig
+	}
+	return d.configPath
+}
+
+// runner returns the configured commandRunner, defaulting to execOutput.
+func (d *ContainerdDetector) runner() commandRunner {
+	if d.run != nil {
+		return d.run
+	}
+	return execOutput
+}
+
+// dialOptions returns the gRPC options used for CRI connections.
+func (d *ContainerdDetector) dialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	}
+	if d.userAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(d.userAgent))
+	}
+	if d.namespace != "" {
+		opts = append(opts, grpc.WithUnaryInterceptor(namespaceInterceptor(d.namespace)))
+	}
+	return opts
+}
+
+// namespaceInterceptor adds the containerd namespace header to each CRI call.
+func namespaceInterceptor(namespace string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, containerdNamespaceHeader, namespace)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// dial creates a gRPC client for the CRI socket.
+// grpc.NewClient connects lazily, so errors surface on the first call.
+func (d *ContainerdDetector) dial(socketPath string) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient("unix://"+socketPath, d.dialOptions()...)
 	if err != nil {
-		return "", fmt.Errorf("failed to create gRPC client: %w", err)
+		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+	return conn, nil
+}
+
+// dialEndpoint creates a gRPC client for a local or SSH-forwarded CRI socket.
+func (d *ContainerdDetector) dialEndpoint(ep endpoint) (*grpc.ClientConn, error) {
+	if !ep.remote() {
+		return d.dial(ep.path)
+	}
+	if d.ssh == nil {
+		return nil, fmt.Errorf("endpoint %s://%s%s requires an SSH dialer", ep.scheme, ep.addr, ep.path)
 	}
-	defer func() {
-		if closeErr := conn.Close(); closeErr != nil {
-			// Log or handle close error if needed
-			// In detection context, we can ignore close errors
-			_ = closeErr
-		}
-	}()
 
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return d.ssh.DialUnix(ctx, ep.user, ep.addr, ep.path)
+	}
+	conn, err := grpc.NewClient("passthrough:///"+ep.addr,
+		append(d.dialOptions(), grpc.WithContextDialer(dialer))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+	return conn, nil
+}
+
+// closeConn closes a CRI connection, stopping its transport and reconnect
+// goroutines. In detection context, we can ignore close errors, including
+// the one from closing an already-closed connection.
+func closeConn(conn *grpc.ClientConn) {
+	_ = conn.Close()
+}
+
+// getVersion retrieves the CRI Version response from containerd
+func (d *ContainerdDetector) getVersion(ctx context.Context, conn *grpc.ClientConn) (*runtimeapi.VersionResponse, error) {
 	// Create CRI runtime service client
 	client := runtimeapi.NewRuntimeServiceClient(conn)
 
-	// Call Version API
-	resp, err := client.Version(ctx, &runtimeapi.VersionRequest{
-		Version: "v1", // CRI API version
+	// Call Version API, with a fresh timeout for each attempt
+	var resp *runtimeapi.VersionResponse
+	err := d.retry.do(ctx, conn, func() error {
+		callCtx, cancel := context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+
+		var err error
+		resp, err = client.Version(callCtx, &runtimeapi.VersionRequest{
+			Version: "v1", // CRI API version
+		})
+		return err
 	})
 	if err != nil {
-		return "", fmt.Errorf("CRI Version call failed: %w", err)
+		// NewClient connects lazily, so the state tells a daemon that never
+		// accepted the connection apart from one that accepted and stalled
+		return nil, fmt.Errorf("CRI Version call failed (connection %s): %w",
+			strings.ToLower(conn.GetState().String()), err)
+	}
+
+	return resp, nil
+}
+
+// imageServiceReady confirms the CRI image service responds, using the cheap ListImages call.
+// A failure here explains image pull problems distinct from runtime service health.
+func (d *ContainerdDetector) imageServiceReady(ctx context.Context, conn *grpc.ClientConn) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	client := runtimeapi.NewImageServiceClient(conn)
+	if _, err := client.ListImages(ctx, &runtimeapi.ListImagesRequest{}); err != nil {
+		return fmt.Errorf("CRI ListImages call failed: %w", err)
 	}
+	return nil
+}
 
-	return resp.RuntimeVersion, nil
+// checkpointSupported reports whether the runtime implements the CRI
+// CheckpointContainer RPC used by Kubernetes forensic checkpointing.
+// The call is made with an empty request, which a supporting runtime rejects
+// as invalid; only Unimplemented means no support. ok is false when the
+// answer is inconclusive (e.g. the runtime is unreachable).
+func (d *ContainerdDetector) checkpointSupported(ctx context.Context, conn *grpc.ClientConn) (supported, ok bool) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	client := runtimeapi.NewRuntimeServiceClient(conn)
+	_, err := client.CheckpointContainer(ctx, &runtimeapi.CheckpointContainerRequest{})
+	switch status.Code(err) {
+	case codes.Unimplemented:
+		return false, true
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return false, false
+	default:
+		return true, true
+	}
+}
+
+// containerdSpecVersions maps containerd release series to the OCI runtime
+// spec version of the runtime-spec module each one builds against, which is
+// the version written into the specs it generates.
+var containerdSpecVersions = map[[2]int]string{
+	{1, 6}: "1.0.2-dev",
+	{1, 7}: "1.1.0",
+	{2, 0}: "1.2.0",
+	{2, 1}: "1.2.1",
+}
+
+// containerdSpecVersion returns the OCI runtime spec version generated by the
+// given containerd version. containerd does not report it over CRI, so it is
+// looked up by release series. Returns "" for unparseable or unknown releases.
+func containerdSpecVersion(version string) string {
+	v, err := parseSemver(version)
+	if err != nil {
+		return ""
+	}
+	return containerdSpecVersions[[2]int{v.core[0], v.core[1]}]
 }
@@ -5,9 +5,107 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 )
 
+// fakeRuntimeService is a minimal CRI runtime service for tests.
+type fakeRuntimeService struct {
+	runtimeapi.UnimplementedRuntimeServiceServer
+
+	version   string
+	name      string // RuntimeName reported by Version; empty means containerd
+	userAgent chan string
+	info      map[string]string // Verbose Status info, e.g. {"config": "{...}"}
+	handlers  []*runtimeapi.RuntimeHandler
+	features  *runtimeapi.RuntimeFeatures
+}
+
+// Status reports a ready runtime with the configured handlers, features, and verbose info.
+func (f *fakeRuntimeService) Status(_ context.Context, req *runtimeapi.StatusRequest) (*runtimeapi.StatusResponse, error) {
+	resp := &runtimeapi.StatusResponse{
+		Status: &runtimeapi.RuntimeStatus{
+			Conditions: []*runtimeapi.RuntimeCondition{
+				{Type: runtimeapi.RuntimeReady, Status: true},
+				{Type: runtimeapi.NetworkReady, Status: true},
+			},
+		},
+		RuntimeHandlers: f.handlers,
+		Features:        f.features,
+	}
+	if req.GetVerbose() {
+		resp.Info = f.info
+	}
+	return resp, nil
+}
+
+// Version reports the configured version and records the caller's user-agent.
+func (f *fakeRuntimeService) Version(ctx context.Context, _ *runtimeapi.VersionRequest) (*runtimeapi.VersionResponse, error) {
+	if f.userAgent != nil {
+		md, _ := metadata.FromIncomingContext(ctx)
+		f.userAgent <- strings.Join(md.Get("user-agent"), " ")
+	}
+	name := f.name
+	if name == "" {
+		name = Containerd
+	}
+	return &runtimeapi.VersionResponse{
+		Version:           "0.1.0",
+		RuntimeName:       name,
+		RuntimeVersion:    f.version,
+		RuntimeApiVersion: "v1",
+	}, nil
+}
+
+// fakeImageService is a minimal CRI image service for tests.
+type fakeImageService struct {
+	runtimeapi.UnimplementedImageServiceServer
+
+	err error
+}
+
+// ListImages returns an empty list, or the configured error.
+func (f *fakeImageService) ListImages(_ context.Context, _ *runtimeapi.ListImagesRequest) (*runtimeapi.ListImagesResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &runtimeapi.ListImagesResponse{}, nil
+}
+
+// startFakeCRIServer serves svc (and images, if non-nil) on a temporary Unix socket
+// and returns the socket path.
+func startFakeCRIServer(t *testing.T, svc runtimeapi.RuntimeServiceServer, images runtimeapi.ImageServiceServer) string {
+	t.Helper()
+
+	socketPath := filepath.Join(t.TempDir(), "cri.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to create Unix socket: %v", err)
+	}
+
+	server := grpc.NewServer()
+	runtimeapi.RegisterRuntimeServiceServer(server, svc)
+	if images != nil {
+		runtimeapi.RegisterImageServiceServer(server, images)
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	return socketPath
+}
+
 // createTestSocket creates a Unix socket for testing and returns a cleanup function
 func createTestSocket(t *testing.T, socketName string) (string, func()) {
 	t.Helper()
@@ -133,7 +231,7 @@ func TestContainerdDetector_findSocket(t *testing.T) {
 			detector, cleanup := tt.setupFunc(t)
 			defer cleanup()
 
-			gotPath, err := detector.findSocket()
+			gotPath, _, err := detector.findSocket()
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("findSocket() error = %v, wantErr %v", err, tt.wantErr)
@@ -288,3 +386,523 @@ func TestContainerdDetector_Detect_Integration(t *testing.T) {
 
 	t.Logf("Detected containerd: version=%s, path=%s", runtime.Version, runtime.Path)
 }
+
+func TestContainerdDetector_SocketPrecedence(t *testing.T) {
+	// Modifies XDG_RUNTIME_DIR, so can't run parallel
+
+	systemSocket, cleanupSystem := createTestSocket(t, "system.sock")
+	defer cleanupSystem()
+	rootlessSocket, cleanupRootless := createTestSocket(t, "rootless.sock")
+	defer cleanupRootless()
+
+	t.Setenv("XDG_RUNTIME_DIR", filepath.Dir(rootlessSocket))
+
+	paths := []string{systemSocket, rootlessSocket}
+
+	tests := []struct {
+		name     string
+		opts     []ContainerdOption
+		wantPath string
+	}{
+		{
+			name:     "default is system first",
+			opts:     nil,
+			wantPath: systemSocket,
+		},
+		{
+			name:     "system first",
+			opts:     []ContainerdOption{WithSocketPrecedence(SystemFirst)},
+			wantPath: systemSocket,
+		},
+		{
+			name:     "rootless first",
+			opts:     []ContainerdOption{WithSocketPrecedence(RootlessFirst)},
+			wantPath: rootlessSocket,
+		},
+		{
+			name: "custom order",
+			opts: []ContainerdOption{WithSocketOrder(func(p []string) []string {
+				return []string{p[1], p[0]}
+			})},
+			wantPath: rootlessSocket,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewContainerdDetector(tt.opts...)
+			detector.socketPaths = paths
+
+			got, _, err := detector.findSocket()
+			if err != nil {
+				t.Fatalf("findSocket() error = %v", err)
+			}
+			if got != tt.wantPath {
+				t.Errorf("findSocket() = %q, want %q", got, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_UserAgent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []ContainerdOption
+		want string
+	}{
+		{
+			name: "default user agent",
+			opts: nil,
+			want: defaultUserAgent,
+		},
+		{
+			name: "custom user agent",
+			opts: []ContainerdOption{WithUserAgent("my-tool/2.0")},
+			want: "my-tool/2.0",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := &fakeRuntimeService{version: "1.7.0", userAgent: make(chan string, 1)}
+			socketPath := startFakeCRIServer(t, svc, nil)
+
+			detector := NewContainerdDetector(tt.opts...)
+			detector.socketPaths = []string{socketPath}
+
+			if _, err := detector.Detect(context.Background()); err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			// gRPC appends its own token, e.g. "otc/0.1.0-dev grpc-go/1.76.0"
+			if got := <-svc.userAgent; !strings.HasPrefix(got, tt.want+" ") {
+				t.Errorf("user-agent = %q, want prefix %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// namespaceRecordingService is a fake CRI runtime service that records the
+// containerd namespace header of each Status call.
+type namespaceRecordingService struct {
+	*fakeRuntimeService
+	namespaces chan []string
+}
+
+// Status records the caller's containerd namespace and reports a ready runtime.
+func (s namespaceRecordingService) Status(ctx context.Context, req *runtimeapi.StatusRequest) (*runtimeapi.StatusResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	select {
+	case s.namespaces <- md.Get(containerdNamespaceHeader):
+	default:
+	}
+	return s.fakeRuntimeService.Status(ctx, req)
+}
+
+func TestContainerdDetector_Namespace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		detector func(socket string) *ContainerdDetector
+		want     []string
+	}{
+		{
+			name: "default namespace",
+			detector: func(socket string) *ContainerdDetector {
+				return NewContainerdDetector(WithEndpoint(socket))
+			},
+			want: []string{"k8s.io"},
+		},
+		{
+			name: "custom namespace",
+			detector: func(socket string) *ContainerdDetector {
+				return NewContainerdDetector(WithEndpoint(socket), WithNamespace("default"))
+			},
+			want: []string{"default"},
+		},
+		{
+			name: "no namespace",
+			detector: func(socket string) *ContainerdDetector {
+				return NewContainerdDetector(WithEndpoint(socket), WithNamespace(""))
+			},
+			want: nil,
+		},
+		{
+			name: "detector option",
+			detector: func(socket string) *ContainerdDetector {
+				cri := NewContainerdDetector(WithEndpoint(socket))
+				NewDetector(nil, cri, nil, WithCRINamespace("moby"))
+				return cri
+			},
+			want: []string{"moby"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := namespaceRecordingService{
+				fakeRuntimeService: &fakeRuntimeService{version: "1.7.0"},
+				namespaces:         make(chan []string, 1),
+			}
+			socket := startFakeCRIServer(t, svc, nil)
+
+			if _, err := tt.detector(socket).Detect(context.Background()); err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			if got := <-svc.namespaces; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("containerd-namespace = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_ImageServiceReady(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		images runtimeapi.ImageServiceServer
+		want   string
+	}{
+		{
+			name:   "image service responsive",
+			images: &fakeImageService{},
+			want:   "true",
+		},
+		{
+			name:   "image service failing",
+			images: &fakeImageService{err: status.Error(codes.Internal, "registry config invalid")},
+			want:   "false",
+		},
+		{
+			name:   "image service not registered",
+			images: nil,
+			want:   "false",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			socketPath := startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.0"}, tt.images)
+
+			detector := NewContainerdDetector()
+			detector.socketPaths = []string{socketPath}
+
+			runtimes, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if got := runtimes[0].Capabilities["imageServiceReady"]; got != tt.want {
+				t.Errorf("imageServiceReady = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// checkpointingRuntimeService is a fake CRI runtime service implementing CheckpointContainer.
+type checkpointingRuntimeService struct {
+	fakeRuntimeService
+}
+
+// CheckpointContainer rejects requests without a container ID, like real runtimes.
+func (f *checkpointingRuntimeService) CheckpointContainer(_ context.Context, req *runtimeapi.CheckpointContainerRequest) (*runtimeapi.CheckpointContainerResponse, error) {
+	if req.GetContainerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "container ID is required")
+	}
+	return &runtimeapi.CheckpointContainerResponse{}, nil
+}
+
+func TestContainerdDetector_CRICheckpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		svc  runtimeapi.RuntimeServiceServer
+		want string
+	}{
+		{
+			name: "checkpoint implemented",
+			svc:  &checkpointingRuntimeService{fakeRuntimeService{version: "1.7.0"}},
+			want: "true",
+		},
+		{
+			name: "checkpoint unimplemented",
+			svc:  &fakeRuntimeService{version: "1.6.0"},
+			want: "false",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector := NewContainerdDetector()
+			detector.socketPaths = []string{startFakeCRIServer(t, tt.svc, nil)}
+
+			runtimes, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if got := runtimes[0].Capabilities["criCheckpoint"]; got != tt.want {
+				t.Errorf("criCheckpoint = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// detectWithConfig runs containerd detection against a fake CRI server whose
+// verbose Status reports config as its CRI plugin config JSON.
+func detectWithConfig(t *testing.T, config string) Runtime {
+	t.Helper()
+
+	svc := &fakeRuntimeService{version: "1.7.0", info: map[string]string{"config": config}}
+	socketPath := startFakeCRIServer(t, svc, nil)
+
+	detector := NewContainerdDetector()
+	detector.socketPaths = []string{socketPath}
+
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	return runtimes[0]
+}
+
+func TestContainerdDetector_DefaultUlimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{
+			name:   "ulimits configured",
+			config: `{"sandboxImage": "registry.k8s.io/pause:3.9", "defaultUlimits": ["nofile=1048576:1048576", "nproc=4096:8192"]}`,
+			want:   "nofile=1048576:1048576,nproc=4096:8192",
+		},
+		{
+			name:   "ulimits not configured",
+			config: `{"sandboxImage": "registry.k8s.io/pause:3.9"}`,
+			want:   "",
+		},
+		{
+			name:   "config unreadable",
+			config: `not json`,
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := detectWithConfig(t, tt.config)
+			if got := rt.Capabilities["defaultUlimits"]; got != tt.want {
+				t.Errorf("defaultUlimits = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_SandboxImage(t *testing.T) {
+	t.Parallel()
+
+	rt := detectWithConfig(t, `{"sandboxImage": "registry.k8s.io/pause:3.9"}`)
+	if got := rt.Capabilities["sandboxImage"]; got != "registry.k8s.io/pause:3.9" {
+		t.Errorf("sandboxImage = %q, want %q", got, "registry.k8s.io/pause:3.9")
+	}
+}
+
+// hangingRuntimeService is a CRI runtime service whose Version never answers
+// before the caller gives up.
+type hangingRuntimeService struct {
+	runtimeapi.UnimplementedRuntimeServiceServer
+}
+
+// Version blocks until the client cancels the call.
+func (hangingRuntimeService) Version(ctx context.Context, _ *runtimeapi.VersionRequest) (*runtimeapi.VersionResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// Checks process-wide goroutines, so can't run parallel
+func TestContainerdDetector_NoLeaksOnTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	const attempts = 20
+
+	tests := []struct {
+		name   string
+		socket func(t *testing.T) string
+		ctx    func() (context.Context, context.CancelFunc)
+	}{
+		{
+			name: "daemon never answers",
+			socket: func(t *testing.T) string {
+				return startFakeCRIServer(t, hangingRuntimeService{}, nil)
+			},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+		},
+		{
+			name: "socket never accepts",
+			socket: func(t *testing.T) string {
+				path, cleanup := createTestSocket(t, "stalled.sock")
+				t.Cleanup(cleanup)
+				return path
+			},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+		},
+		{
+			name: "caller cancels mid-dial",
+			socket: func(t *testing.T) string {
+				return startFakeCRIServer(t, hangingRuntimeService{}, nil)
+			},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		// Subtests finish, and stop their servers, before the leak check runs
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewContainerdDetector(WithTimeout(50 * time.Millisecond))
+			detector.socketPaths = []string{tt.socket(t)}
+
+			for i := 0; i < attempts; i++ {
+				ctx, cancel := tt.ctx()
+				_, err := detector.Detect(ctx)
+				cancel()
+				if err == nil {
+					t.Fatalf("Detect() attempt %d succeeded, want timeout error", i)
+				}
+			}
+		})
+	}
+}
+
+func TestNewContainerdDetector_RootlessSocket(t *testing.T) {
+	// Modifies XDG_RUNTIME_DIR and HOME, so can't run parallel
+
+	tests := []struct {
+		name       string
+		runtimeDir string
+		want       string
+	}{
+		{
+			name:       "XDG_RUNTIME_DIR set",
+			runtimeDir: "/run/user/1000",
+			want:       "/run/user/1000/containerd/containerd.sock",
+		},
+		{
+			name:       "XDG_RUNTIME_DIR unset",
+			runtimeDir: "",
+			want:       filepath.Join("/run/user", strconv.Itoa(os.Getuid()), "containerd", "containerd.sock"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_RUNTIME_DIR", tt.runtimeDir)
+			t.Setenv("HOME", t.TempDir()) // No Lima instances
+
+			paths := NewContainerdDetector().socketPaths
+			want := append(append([]string(nil), containerdSocketPaths...), tt.want)
+			if !reflect.DeepEqual(paths, want) {
+				t.Errorf("socketPaths = %v, want rootful paths then %s", paths, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_Detect_Rootless(t *testing.T) {
+	// Modifies XDG_RUNTIME_DIR, so can't run parallel
+
+	runtimeDir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+
+	// Serve on the rootless path only; the rootful paths don't exist in tests
+	socket := startFakeCRIServer(t, &fakeRuntimeService{version: "2.0.0"}, nil)
+	rootless := filepath.Join(runtimeDir, "containerd", "containerd.sock")
+	if err := os.MkdirAll(filepath.Dir(rootless), 0755); err != nil {
+		t.Fatalf("failed to create socket dir: %v", err)
+	}
+	if err := os.Symlink(socket, rootless); err != nil {
+		t.Fatalf("failed to link rootless socket: %v", err)
+	}
+
+	detector := NewContainerdDetector()
+	detector.socketPaths = []string{filepath.Join(t.TempDir(), "rootful.sock"), rootlessContainerdSocket()}
+
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if runtimes[0].Path != rootless {
+		t.Errorf("Path = %q, want rootless socket %q", runtimes[0].Path, rootless)
+	}
+	if !runtimes[0].Rootless {
+		t.Error("Rootless = false, want true for the XDG_RUNTIME_DIR socket")
+	}
+}
+
+func TestContainerdDetector_EnvSocket(t *testing.T) {
+	// Modifies OTC_CONTAINERD_SOCKET, so can't run parallel
+
+	envSocket, cleanupEnv := createTestSocket(t, "custom.sock")
+	defer cleanupEnv()
+	defaultSocket, cleanupDefault := createTestSocket(t, "containerd.sock")
+	defer cleanupDefault()
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "env socket tried first",
+			value: envSocket,
+			want:  envSocket,
+		},
+		{
+			name:  "missing env socket falls through to defaults",
+			value: filepath.Join(t.TempDir(), "missing.sock"),
+			want:  defaultSocket,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTC_CONTAINERD_SOCKET", tt.value)
+
+			detector := &ContainerdDetector{socketPaths: []string{defaultSocket}}
+			got, _, err := detector.findSocket()
+			if err != nil {
+				t.Fatalf("findSocket() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("findSocket() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// criConfig is the runtime configuration reported in the "config" entry of a
+// verbose CRI Status response. The schema is implementation-specific (containerd
+// uses camelCase keys), so it is kept as generic JSON and queried by key path.
+type criConfig map[string]any
+
+// getCRIStatus calls the CRI Status RPC in verbose mode, so the response
+// carries the runtime config in Info alongside the runtime handlers.
+func getCRIStatus(ctx context.Context, conn *grpc.ClientConn) (*runtimeapi.StatusResponse, error) {
+	client := runtimeapi.NewRuntimeServiceClient(conn)
+	resp, err := client.Status(ctx, &runtimeapi.StatusRequest{Verbose: true})
+	if err != nil {
+		return nil, fmt.Errorf("CRI Status call failed: %w", err)
+	}
+	return resp, nil
+}
+
+// parseCRIConfig decodes the "config" entry from verbose CRI Status info.
+// Returns error if the entry is missing or not a JSON object.
+func parseCRIConfig(info map[string]string) (criConfig, error) {
+	raw, ok := info["config"]
+	if !ok {
+		return nil, fmt.Errorf("CRI status info has no config entry")
+	}
+
+	var cfg criConfig
+	if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse CRI config: %w", err)
+	}
+	return cfg, nil
+}
+
+// lookup returns the value at the given key path, descending through nested objects.
+func (c criConfig) lookup(path ...string) (any, bool) {
+	var current any = map[string]any(c)
+	for _, key := range path {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// str returns the value at the key path formatted as a string.
+// Lists are joined with commas; missing keys and null values yield "".
+func (c criConfig) str(path ...string) string {
+	value, ok := c.lookup(path...)
+	if !ok || value == nil {
+		return ""
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
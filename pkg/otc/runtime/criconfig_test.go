@@ -0,0 +1,74 @@
+package runtime
+
+import "testing"
+
+func TestParseCRIConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		info    map[string]string
+		path    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "nested string",
+			info: map[string]string{"config": `{"containerd": {"defaultRuntimeName": "runc"}}`},
+			path: []string{"containerd", "defaultRuntimeName"},
+			want: "runc",
+		},
+		{
+			name: "list joined",
+			info: map[string]string{"config": `{"defaultUlimits": ["nofile=1024:2048"]}`},
+			path: []string{"defaultUlimits"},
+			want: "nofile=1024:2048",
+		},
+		{
+			name: "number and bool",
+			info: map[string]string{"config": `{"enableSelinux": true}`},
+			path: []string{"enableSelinux"},
+			want: "true",
+		},
+		{
+			name: "missing key",
+			info: map[string]string{"config": `{"containerd": {}}`},
+			path: []string{"containerd", "snapshotter"},
+			want: "",
+		},
+		{
+			name: "path through non-object",
+			info: map[string]string{"config": `{"sandboxImage": "pause"}`},
+			path: []string{"sandboxImage", "tag"},
+			want: "",
+		},
+		{
+			name:    "no config entry",
+			info:    map[string]string{"golang": `"go1.21"`},
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			info:    map[string]string{"config": `{`},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := parseCRIConfig(tt.info)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCRIConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := cfg.str(tt.path...); got != tt.want {
+				t.Errorf("str(%v) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
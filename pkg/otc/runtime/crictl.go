@@ -0,0 +1,56 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// crictlVersionOutput is the JSON printed by `crictl version --output json`.
+type crictlVersionOutput struct {
+	RuntimeName    string `json:"runtimeName"`
+	RuntimeVersion string `json:"runtimeVersion"`
+}
+
+// crictlVersion queries a CRI runtime through the crictl CLI. It is a fallback
+// for hosts where the direct gRPC call fails but crictl works. When endpoint is
+// set, crictl is pointed at it so the version describes that daemon; otherwise
+// crictl's own endpoint configuration is used.
+// Returns error if crictl is missing, fails, or reports a different runtime.
+func crictlVersion(ctx context.Context, run commandRunner, runtimeName, endpoint string) (string, error) {
+	path, err := exec.LookPath("crictl")
+	if err != nil {
+		return "", fmt.Errorf("crictl not found in PATH: %w", err)
+	}
+
+	var args []string
+	if endpoint != "" {
+		args = append(args, "--runtime-endpoint", endpoint)
+	}
+	output, err := run(ctx, path, append(args, "version", "--output", "json")...)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute crictl version: %w", err)
+	}
+
+	return parseCrictlVersion(output, runtimeName)
+}
+
+// parseCrictlVersion extracts the runtime version from crictl JSON output,
+// verifying the reported runtime matches runtimeName.
+func parseCrictlVersion(output []byte, runtimeName string) (string, error) {
+	var v crictlVersionOutput
+	if err := json.Unmarshal(output, &v); err != nil {
+		return "", fmt.Errorf("failed to parse crictl version output: %w", err)
+	}
+
+	if !strings.Contains(v.RuntimeName, runtimeName) {
+		return "", fmt.Errorf("crictl is configured for %q, not %s", v.RuntimeName, runtimeName)
+	}
+	if v.RuntimeVersion == "" {
+		return "", fmt.Errorf("crictl reported no runtime version")
+	}
+
+	return v.RuntimeVersion, nil
+}
@@ -0,0 +1,104 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCrictlVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "containerd",
+			output: `{"version": "0.1.0", "runtimeName": "containerd", "runtimeVersion": "v1.7.2", "runtimeApiVersion": "v1"}`,
+			want:   "v1.7.2",
+		},
+		{
+			name:    "different runtime",
+			output:  `{"version": "0.1.0", "runtimeName": "cri-o", "runtimeVersion": "1.28.1", "runtimeApiVersion": "v1"}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing version",
+			output:  `{"runtimeName": "containerd"}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			output:  "Version:  0.1.0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseCrictlVersion([]byte(tt.output), Containerd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCrictlVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseCrictlVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_Detect_CrictlFallback(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	writeFakeBinary(t, binDir, "crictl",
+		`echo "$@" > `+argsFile+`
+echo '{"version": "0.1.0", "runtimeName": "containerd", "runtimeVersion": "v1.7.2", "runtimeApiVersion": "v1"}'`)
+	t.Setenv("PATH", binDir)
+
+	// A socket that isn't a CRI server makes the direct gRPC call fail
+	socketPath, cleanup := createTestSocket(t, "containerd.sock")
+	defer cleanup()
+
+	detector := NewContainerdDetector()
+	detector.socketPaths = []string{socketPath}
+	detector.timeout = 200 * time.Millisecond
+
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(runtimes) != 1 || runtimes[0].Version != "v1.7.2" {
+		t.Fatalf("Detect() = %+v, want containerd v1.7.2", runtimes)
+	}
+
+	// crictl must query the socket that was found, not its own configured one
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("failed to read crictl args: %v", err)
+	}
+	if want := "--runtime-endpoint unix://" + socketPath + " version --output json"; strings.TrimSpace(string(args)) != want {
+		t.Errorf("crictl args = %q, want %q", strings.TrimSpace(string(args)), want)
+	}
+
+	// The CRI probes have no connection left to answer them
+	rt := runtimes[0]
+	for _, key := range []string{"imageServiceReady", "criCheckpoint", "handlers"} {
+		if v, ok := rt.Capabilities[key]; ok {
+			t.Errorf("Capabilities[%q] = %q, want unset after crictl fallback", key, v)
+		}
+	}
+	if len(rt.Handlers) != 0 {
+		t.Errorf("Handlers = %v, want none after crictl fallback", rt.Handlers)
+	}
+}
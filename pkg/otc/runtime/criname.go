@@ -0,0 +1,21 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkCRIRuntimeName confirms the RuntimeName a CRI Version response reports
+// belongs to the runtime want (Containerd or CRIO), so a socket at one
+// runtime's path served by another, e.g. CRI-O's socket symlinked into
+// containerd's path, is not mislabeled. Names are compared case-insensitively
+// ignoring dashes, since CRI-O reports "cri-o". An empty name can't be checked
+// and is accepted.
+// Returns error wrapping ErrRuntimeMismatch if the name belongs to another runtime.
+func checkCRIRuntimeName(want, socket, got string) error {
+	normalized := strings.ReplaceAll(strings.ToLower(got), "-", "")
+	if got == "" || strings.Contains(normalized, want) {
+		return nil
+	}
+	return fmt.Errorf("CRI socket %s %w: serves %q, not %s", socket, ErrRuntimeMismatch, got, want)
+}
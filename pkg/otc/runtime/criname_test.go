@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCheckCRIRuntimeName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		want    string
+		got     string
+		wantErr bool
+	}{
+		{name: "containerd", want: Containerd, got: "containerd"},
+		{name: "cri-o", want: CRIO, got: "cri-o"},
+		{name: "case and dashes ignored", want: CRIO, got: "CRI-O"},
+		{name: "empty name accepted", want: Containerd, got: ""},
+		{name: "cri-o on containerd path", want: Containerd, got: "cri-o", wantErr: true},
+		{name: "containerd on cri-o path", want: CRIO, got: "containerd", wantErr: true},
+		{name: "other implementation", want: Containerd, got: "cri-dockerd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkCRIRuntimeName(tt.want, "/run/containerd/containerd.sock", tt.got)
+			if tt.wantErr != errors.Is(err, ErrRuntimeMismatch) {
+				t.Errorf("checkCRIRuntimeName(%q, %q) error = %v, wantErr %v", tt.want, tt.got, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCRIDetectors_APIVersionAndMismatch(t *testing.T) {
+	t.Parallel()
+
+	containerdSocket := startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.13"}, nil)
+	crioSocket := startFakeCRIServer(t, &fakeRuntimeService{version: "1.30.4", name: "cri-o"}, nil)
+
+	containerd := NewContainerdDetector()
+	containerd.socketPaths = []string{containerdSocket}
+	runtimes, err := containerd.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("containerd Detect() error = %v", err)
+	}
+	if got := runtimes[0].APIVersion; got != "v1" {
+		t.Errorf("containerd APIVersion = %q, want %q", got, "v1")
+	}
+
+	crio := NewCRIODetector()
+	crio.socketPaths = []string{crioSocket}
+	runtimes, err = crio.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("crio Detect() error = %v", err)
+	}
+	if got := runtimes[0].APIVersion; got != "v1" {
+		t.Errorf("crio APIVersion = %q, want %q", got, "v1")
+	}
+
+	// CRI-O's socket found at containerd's path must not be reported as containerd
+	containerd.socketPaths = []string{crioSocket}
+	if _, err := containerd.Detect(context.Background()); !errors.Is(err, ErrRuntimeMismatch) {
+		t.Errorf("containerd Detect() on CRI-O socket error = %v, want %v", err, ErrRuntimeMismatch)
+	}
+
+	crio.socketPaths = []string{containerdSocket}
+	if _, err := crio.Detect(context.Background()); !errors.Is(err, ErrRuntimeMismatch) {
+		t.Errorf("crio Detect() on containerd socket error = %v, want %v", err, ErrRuntimeMismatch)
+	}
+}
@@ -0,0 +1,193 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// Standard CRI-O socket paths in order of preference
+var crioSocketPaths = []string{
+	"/var/run/crio/crio.sock", // Default in crio.conf
+	"/run/crio/crio.sock",     // Alternative - /var/run is a symlink on modern systems
+}
+
+// errNoCRIOSocket is returned when none of the CRI-O socket paths is a socket.
+var errNoCRIOSocket = errors.New("no CRI-O socket found")
+
+// CRIODetector detects CRI-O via CRI socket
+type CRIODetector struct {
+	socketPaths []string
+	timeout     time.Duration
+	// timeoutWarning reports an invalid OTC_DETECT_TIMEOUT with each detection
+	timeoutWarning error
+	userAgent      string
+	retry          criRetry     // Retries of the Version call; zero makes one attempt
+	logger         *slog.Logger // Debug tracing; nil logs nothing
+}
+
+// NewCRIODetector creates a new CRI-O detector with default settings.
+// Like the containerd detector, the per-call CRI timeout is read from
+// OTC_DETECT_TIMEOUT when set, and a socket named by OTC_CRIO_SOCKET is
+// tried ahead of the standard paths.
+func NewCRIODetector() *CRIODetector {
+	timeout, timeoutErr := getTimeoutFromEnv(defaultDetectTimeout)
+	return &CRIODetector{
+		socketPaths:    crioSocketPaths,
+		timeout:        timeout,
+		timeoutWarning: timeoutErr,
+		userAgent:      defaultUserAgent,
+	}
+}
+
+// setTimeout sets the per-call CRI timeout, overriding OTC_DETECT_TIMEOUT.
+func (d *CRIODetector) setTimeout(timeout time.Duration) {
+	d.timeout = timeout
+	d.timeoutWarning = nil
+}
+
+// setRetry sets how the Version call is retried, for WithCRIRetry.
+func (d *CRIODetector) setRetry(attempts int, backoff time.Duration) {
+	d.retry = criRetry{attempts: attempts, backoff: backoff}
+}
+
+// setLogger sets the logger used to trace socket discovery.
+func (d *CRIODetector) setLogger(logger *slog.Logger) {
+	d.logger = logger
+}
+
+// appendSocketPaths adds socket paths to search after the standard ones.
+func (d *CRIODetector) appendSocketPaths(paths []string) {
+	d.socketPaths = append(append([]string(nil), d.socketPaths...), paths...)
+}
+
+// Detect attempts to detect CRI-O via CRI socket
+func (d *CRIODetector) Detect(ctx context.Context) ([]Runtime, error) {
+	socket, warnings, err := d.findSocket()
+	if errors.Is(err, ErrSocketNotAccessible) {
+		return nil, fmt.Errorf("crio %w", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("crio socket %w: %w", ErrRuntimeNotFound, errors.Join(append([]error{err}, warnings...)...))
+	}
+
+	conn, err := grpc.NewClient("unix://"+socket,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUserAgent(d.userAgent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crio version: failed to create gRPC client: %w", err)
+	}
+	defer closeConn(conn)
+
+	resp, err := d.getVersion(ctx, conn)
+	loggerOrDiscard(d.logger).Debug("CRI version queried", "runtime", CRIO, "endpoint", socket, "version", resp.GetRuntimeVersion(), "error", err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crio version: %w", err)
+	}
+	if err := checkCRIRuntimeName(CRIO, socket, resp.GetRuntimeName()); err != nil {
+		return nil, err
+	}
+
+	runtime := Runtime{
+		Name:       CRIO,
+		Type:       TypeCRI,
+		Version:    resp.GetRuntimeVersion(),
+		APIVersion: resp.GetRuntimeApiVersion(),
+		Path:       socket,
+		Priority:   PriorityCRI,
+		Handlers:   d.handlers(ctx, conn),
+		Socket:     localSocketInfo(socket),
+	}
+	if d.timeoutWarning != nil {
+		warnings = append(warnings, d.timeoutWarning)
+	}
+	return []Runtime{runtime}, joinWarnings(warnings)
+}
+
+// findSocket returns the first CRI-O socket path that exists and is a socket.
+// A socket named by OTC_CRIO_SOCKET is tried before the standard paths.
+// Broken symlinks passed over on the way are returned as warnings.
+func (d *CRIODetector) findSocket() (string, []error, error) {
+	log := loggerOrDiscard(d.logger)
+	var denied error
+	var warnings []error
+	for _, path := range withEnvSocket(crioSocketEnv, d.socketPaths) {
+		info, err := os.Stat(path)
+		if err != nil {
+			if broken := brokenSymlinkWarning(path); broken != nil {
+				logSocketSkipped(log, CRIO, path, skipBrokenSymlink)
+				warnings = append(warnings, broken)
+			} else if os.IsNotExist(err) {
+				logSocketSkipped(log, CRIO, path, skipNotExist)
+			} else {
+				logSocketSkipped(log, CRIO, path, skipStatFailed)
+			}
+			continue // Socket doesn't exist, try next
+		}
+
+		// Verify it's actually a socket
+		if info.Mode()&os.ModeSocket == 0 {
+			logSocketSkipped(log, CRIO, path, skipNotSocket)
+			continue // Not a socket, try next
+		}
+
+		// Skip a socket we can't connect to, as the containerd detector does
+		if socket, ok := statSocket(info); ok && !socket.Accessible {
+			logSocketSkipped(log, CRIO, path, skipPermission)
+			if denied == nil {
+				denied = socketNotAccessibleError(path, socket, info.Mode().Perm())
+			}
+			continue
+		}
+
+		log.Debug("socket found", "runtime", CRIO, "path", path)
+		return path, warnings, nil
+	}
+
+	if denied != nil {
+		return "", warnings, denied
+	}
+	return "", warnings, errNoCRIOSocket
+}
+
+// getVersion retrieves the CRI Version response from CRI-O
+func (d *CRIODetector) getVersion(ctx context.Context, conn *grpc.ClientConn) (*runtimeapi.VersionResponse, error) {
+	client := runtimeapi.NewRuntimeServiceClient(conn)
+
+	var resp *runtimeapi.VersionResponse
+	err := d.retry.do(ctx, conn, func() error {
+		callCtx, cancel := context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+
+		var err error
+		resp, err = client.Version(callCtx, &runtimeapi.VersionRequest{
+			Version: "v1", // CRI API version
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CRI Version call failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// handlers returns the runtime handler names from the CRI Status response.
+// Best-effort: returns nil if Status is unavailable.
+func (d *CRIODetector) handlers(ctx context.Context, conn *grpc.ClientConn) []string {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	status, err := getCRIStatus(ctx, conn)
+	if err != nil {
+		return nil
+	}
+	return runtimeHandlerNames(status.GetRuntimeHandlers())
+}
@@ -0,0 +1,135 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCRIODetector_Detect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		socket      func(t *testing.T) string
+		wantVersion string
+		wantErr     string
+	}{
+		{
+			name: "crio answering on socket",
+			socket: func(t *testing.T) string {
+				return startFakeCRIServer(t, &fakeRuntimeService{version: "1.30.4", name: "cri-o"}, nil)
+			},
+			wantVersion: "1.30.4",
+		},
+		{
+			name: "no socket found",
+			socket: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "crio.sock")
+			},
+			wantErr: "crio socket not found",
+		},
+		{
+			name: "regular file is not a socket",
+			socket: func(t *testing.T) string {
+				path := filepath.Join(t.TempDir(), "crio.sock")
+				if err := os.WriteFile(path, nil, 0644); err != nil {
+					t.Fatalf("failed to write file: %v", err)
+				}
+				return path
+			},
+			wantErr: "crio socket not found",
+		},
+		{
+			name: "socket not answering",
+			socket: func(t *testing.T) string {
+				path, cleanup := createTestSocket(t, "crio.sock")
+				t.Cleanup(cleanup)
+				return path
+			},
+			wantErr: "failed to get crio version",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector := NewCRIODetector()
+			detector.socketPaths = []string{tt.socket(t)}
+			detector.timeout = 100 * time.Millisecond
+			detector.timeoutWarning = nil
+
+			runtimes, err := detector.Detect(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Detect() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			want := Runtime{Name: CRIO, Type: TypeCRI, Version: tt.wantVersion, Path: detector.socketPaths[0], Priority: PriorityCRI}
+			if len(runtimes) != 1 || runtimes[0].Name != want.Name || runtimes[0].Type != want.Type ||
+				runtimes[0].Version != want.Version || runtimes[0].Path != want.Path || runtimes[0].Priority != want.Priority {
+				t.Errorf("Detect() = %+v, want %+v", runtimes, want)
+			}
+		})
+	}
+}
+
+func TestCRIODetector_NoSocketWrapsSentinel(t *testing.T) {
+	t.Parallel()
+
+	detector := NewCRIODetector()
+	detector.socketPaths = []string{filepath.Join(t.TempDir(), "crio.sock")}
+
+	_, err := detector.Detect(context.Background())
+	if !errors.Is(err, errNoCRIOSocket) {
+		t.Errorf("Detect() error = %v, want wrapping errNoCRIOSocket", err)
+	}
+}
+
+func TestDetector_Detect_OverrideCRIO(t *testing.T) {
+	t.Parallel()
+
+	detector := NewCRIODetector()
+	detector.socketPaths = []string{startFakeCRIServer(t, &fakeRuntimeService{version: "1.30.4", name: "cri-o"}, nil)}
+	detector.timeoutWarning = nil
+
+	d := NewDetector(nil, detector, nil)
+	d.override = CRIO
+
+	result, err := d.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if result.Selected.Name != CRIO || result.Mode != ModeOverride {
+		t.Errorf("Detect() selected %q in mode %q, want crio override", result.Selected.Name, result.Mode)
+	}
+}
+
+func TestCRIODetector_EnvSocket(t *testing.T) {
+	// Modifies OTC_CRIO_SOCKET, so can't run parallel
+
+	socket := startFakeCRIServer(t, &fakeRuntimeService{version: "1.31.0", name: "cri-o"}, nil)
+	t.Setenv("OTC_CRIO_SOCKET", " unix://"+socket+" ")
+
+	detector := NewCRIODetector()
+	detector.socketPaths = []string{filepath.Join(t.TempDir(), "crio.sock")}
+
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if runtimes[0].Path != socket {
+		t.Errorf("Path = %q, want %q", runtimes[0].Path, socket)
+	}
+}
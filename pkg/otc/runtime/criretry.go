@@ -0,0 +1,47 @@
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// criRetry configures retrying a CRI call that fails because the runtime is
+// not yet accepting connections, as happens while a node boots. The zero
+// value makes a single attempt.
+type criRetry struct {
+	attempts int
+	backoff  time.Duration
+}
+
+// do calls call until it succeeds, fails with an error other than
+// codes.Unavailable, or the attempts run out. It waits backoff before the second
+// attempt and doubles the wait after each, and gives up early, returning the
+// last error, if ctx is done or its deadline would pass during the wait.
+// Before each retry conn is told to reconnect at once rather than wait out
+// its own reconnect backoff.
+func (r criRetry) do(ctx context.Context, conn *grpc.ClientConn, call func() error) error {
+	wait := r.backoff
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= r.attempts || status.Code(err) != codes.Unavailable {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		wait *= 2
+		conn.ResetConnectBackoff()
+	}
+}
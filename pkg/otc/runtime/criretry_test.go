@@ -0,0 +1,173 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+func TestCRIRetry_Do(t *testing.T) {
+	t.Parallel()
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	unimplemented := status.Error(codes.Unimplemented, "unknown service")
+
+	tests := []struct {
+		name      string
+		retry     criRetry
+		timeout   time.Duration
+		failures  int   // Calls that fail before one succeeds
+		failWith  error // Error returned by failing calls
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "zero value makes one attempt",
+			failures:  1,
+			failWith:  unavailable,
+			wantCalls: 1,
+			wantErr:   unavailable,
+		},
+		{
+			name:      "succeeds after transient failures",
+			retry:     criRetry{attempts: 4, backoff: time.Millisecond},
+			failures:  2,
+			failWith:  unavailable,
+			wantCalls: 3,
+		},
+		{
+			name:      "attempts exhausted",
+			retry:     criRetry{attempts: 3, backoff: time.Millisecond},
+			failures:  5,
+			failWith:  unavailable,
+			wantCalls: 3,
+			wantErr:   unavailable,
+		},
+		{
+			name:      "other errors not retried",
+			retry:     criRetry{attempts: 3, backoff: time.Millisecond},
+			failures:  1,
+			failWith:  unimplemented,
+			wantCalls: 1,
+			wantErr:   unimplemented,
+		},
+		{
+			name:      "deadline before next attempt",
+			retry:     criRetry{attempts: 3, backoff: time.Minute},
+			timeout:   time.Second,
+			failures:  1,
+			failWith:  unavailable,
+			wantCalls: 1,
+			wantErr:   unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn, err := grpc.NewClient("unix://"+filepath.Join(t.TempDir(), "missing.sock"),
+				grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				t.Fatalf("failed to create gRPC client: %v", err)
+			}
+			defer closeConn(conn)
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			calls := 0
+			err = tt.retry.do(ctx, conn, func() error {
+				calls++
+				if calls <= tt.failures {
+					return tt.failWith
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("do() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("do() made %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWithCRIRetry_SocketNotYetServing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    []DetectorOption
+		wantErr bool
+	}{
+		{name: "single attempt fails", wantErr: true},
+		{name: "retry waits for daemon", opts: []DetectorOption{WithCRIRetry(8, 50*time.Millisecond)}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// A socket file nobody listens on refuses connections, like one
+			// left behind by a containerd that is still starting
+			socketPath := filepath.Join(t.TempDir(), "containerd.sock")
+			listener, err := net.Listen("unix", socketPath)
+			if err != nil {
+				t.Fatalf("failed to create Unix socket: %v", err)
+			}
+			listener.(*net.UnixListener).SetUnlinkOnClose(false)
+			if err := listener.Close(); err != nil {
+				t.Fatalf("failed to close listener: %v", err)
+			}
+
+			server := grpc.NewServer()
+			runtimeapi.RegisterRuntimeServiceServer(server, &fakeRuntimeService{version: "1.7.0"})
+			t.Cleanup(server.Stop)
+
+			if !tt.wantErr {
+				time.AfterFunc(200*time.Millisecond, func() {
+					_ = os.Remove(socketPath)
+					if listener, err := net.Listen("unix", socketPath); err == nil {
+						_ = server.Serve(listener)
+					}
+				})
+			}
+
+			cri := NewContainerdDetector()
+			cri.socketPaths = []string{socketPath}
+			detector := NewDetector(nil, cri, nil, append(tt.opts, WithOverride(""))...)
+
+			result, err := detector.Detect(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Detect() = %+v, want error from refused connection", result.Selected)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if result.Selected.Name != Containerd || result.Selected.Version != "1.7.0" {
+				t.Errorf("Selected = %s, want containerd v1.7.0", result.Selected)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	defaultOnce     sync.Once
+	defaultDetector *Detector
+)
+
+// Default returns a shared Detector configured with the built-in OCI,
+// containerd, CRI-O, Podman, and Docker detectors. It is created on first use, so OTC_RUNTIME is read
+// then and later changes to the variable are not seen.
+//
+// Default is safe for concurrent use. The detector is shared by all callers,
+// so it must not be closed; construct one with NewDetector for custom options.
+func Default() *Detector {
+	defaultOnce.Do(func() {
+		defaultDetector = newDefaultDetector()
+	})
+	return defaultDetector
+}
+
+// DetectDefault detects the container runtimes on this host in one call, for
+// callers that don't need to assemble detectors themselves. It builds a fresh
+// Detector with the built-in OCI, containerd, CRI-O, Podman, and Docker
+// detectors, and runs Detect. OTC_RUNTIME and the other environment
+// variables are read on each call. Use NewDetector for custom options.
+func DetectDefault(ctx context.Context) (*Result, error) {
+	return newDefaultDetector().Detect(ctx)
+}
+
+// newDefaultDetector builds a Detector with the built-in detectors, for
+// Default and DetectDefault.
+func newDefaultDetector() *Detector {
+	return NewDetector(NewOCIDetector(),
+		multiCRIDetector{NewContainerdDetector(), NewCRIODetector()},
+		NewPodmanDetector(),
+		WithDockerDetector(NewDockerDetector()))
+}
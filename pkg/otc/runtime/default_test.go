@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	t.Parallel()
+
+	const callers = 8
+
+	var wg sync.WaitGroup
+	detectors := make([]*Detector, callers)
+	for i := range detectors {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			detectors[i] = Default()
+		}(i)
+	}
+	wg.Wait()
+
+	for i, d := range detectors {
+		if d == nil {
+			t.Fatalf("Default() call %d returned nil", i)
+		}
+		if d != detectors[0] {
+			t.Fatalf("Default() call %d returned a different instance", i)
+		}
+	}
+
+	if detectors[0].oci == nil || detectors[0].cri == nil {
+		t.Error("Default() detector is missing built-in detectors")
+	}
+	if _, ok := detectors[0].cri.(multiCRIDetector); !ok {
+		t.Errorf("Default() CRI detector = %T, want containerd and CRI-O", detectors[0].cri)
+	}
+
+	// Installed runtimes vary by host, so only check the result is well-formed
+	result, err := Default().Detect(context.Background())
+	if err != nil {
+		t.Logf("Detect() error = %v (no runtimes on this host)", err)
+		return
+	}
+	if len(result.Runtimes) > 0 && result.Selected != &result.Runtimes[0] {
+		t.Error("Selected does not point at the highest priority runtime")
+	}
+}
+
+func TestNewDefaultDetector_CRIO(t *testing.T) {
+	t.Parallel()
+
+	// Point the built-in CRI detectors at a host running only CRI-O
+	socket := startFakeCRIServer(t, &fakeRuntimeService{version: "1.30.4", name: "cri-o"}, nil)
+	detector := newDefaultDetector()
+	for _, cri := range detector.cri.(multiCRIDetector) {
+		switch cri := cri.(type) {
+		case *ContainerdDetector:
+			cri.socketPaths = []string{filepath.Join(t.TempDir(), "containerd.sock")}
+		case *CRIODetector:
+			cri.socketPaths = []string{socket}
+		}
+	}
+
+	runtimes, err := detector.cri.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(runtimes) != 1 || runtimes[0].Name != CRIO {
+		t.Errorf("Detect() = %+v, want only %s", runtimes, CRIO)
+	}
+}
+
+func TestDetectDefault(t *testing.T) {
+	t.Parallel()
+
+	// Installed runtimes vary by host, so only check the result is well-formed
+	result, err := DetectDefault(context.Background())
+	if err != nil {
+		t.Logf("DetectDefault() error = %v (no runtimes on this host)", err)
+		return
+	}
+	if len(result.Runtimes) > 0 && result.Selected != &result.Runtimes[0] {
+		t.Error("Selected does not point at the highest priority runtime")
+	}
+	if result.DetectedAt.IsZero() {
+		t.Error("DetectDefault() result has no detection time")
+	}
+}
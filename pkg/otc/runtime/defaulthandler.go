@@ -0,0 +1,45 @@
+package runtime
+
+import (
+	"slices"
+	"strings"
+)
+
+// containerdDefaultRuntime is the handler containerd uses when its config
+// does not name one
+const containerdDefaultRuntime = "runc"
+
+// containerdCRITables are the config tables holding default_runtime_name, for
+// config schema version 1 (plugins.cri), version 2 (the io.containerd.grpc.v1.cri
+// plugin), and version 3 in containerd 2.x (the io.containerd.cri.v1.runtime plugin).
+// Names are compared with quotes removed, so either TOML quoting style matches.
+var containerdCRITables = []string{
+	"plugins.cri.containerd",
+	"plugins.io.containerd.grpc.v1.cri.containerd",
+	"plugins.io.containerd.cri.v1.runtime.containerd",
+}
+
+// containerdDefaultHandler returns the runtime handler containerd launches
+// containers with by default, read from default_runtime_name in its config at
+// configPath. The key may be set in its table or as a dotted key in a parent
+// table, e.g. containerd.default_runtime_name under the CRI plugin table.
+// Returns "runc", containerd's own default, if the config doesn't set it, or
+// "" if the config can't be read.
+func containerdDefaultHandler(configPath string) string {
+	entries, err := readTOMLEntries(configPath)
+	if err != nil {
+		return ""
+	}
+
+	for _, e := range entries {
+		key := e.name()
+		i := strings.LastIndex(key, ".")
+		if e.key == "" || i < 0 || key[i+1:] != "default_runtime_name" || !slices.Contains(containerdCRITables, key[:i]) {
+			continue
+		}
+		if e.value != "" {
+			return e.value
+		}
+	}
+	return containerdDefaultRuntime
+}
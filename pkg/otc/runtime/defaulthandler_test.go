@@ -0,0 +1,149 @@
+package runtime
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContainerdDefaultHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{
+			name:   "version 1",
+			config: "[plugins.cri.containerd]\n  snapshotter = \"overlayfs\"\n  default_runtime_name = \"crun\"\n",
+			want:   Crun,
+		},
+		{
+			name:   "version 2",
+			config: "version = 2\n[plugins.\"io.containerd.grpc.v1.cri\".containerd]\n  default_runtime_name = \"kata\"\n",
+			want:   "kata",
+		},
+		{
+			name:   "version 3 single quoted",
+			config: "version = 3\n[plugins.'io.containerd.cri.v1.runtime'.containerd]\n  default_runtime_name = 'runsc'\n",
+			want:   Runsc,
+		},
+		{
+			name:   "version 3",
+			config: "version = 3\n[plugins.\"io.containerd.cri.v1.runtime\".containerd]\n  default_runtime_name = \"runsc\"\n",
+			want:   Runsc,
+		},
+		{
+			name:   "key in runtime table ignored",
+			config: "version = 2\n[plugins.\"io.containerd.grpc.v1.cri\".containerd.runtimes.kata]\n  default_runtime_name = \"kata\"\n",
+			want:   containerdDefaultRuntime,
+		},
+		{
+			name:   "trailing comments",
+			config: "version = 2\n[plugins.\"io.containerd.grpc.v1.cri\".containerd]  # CRI runtimes\n  default_runtime_name = \"crun\"  # faster startup\n",
+			want:   Crun,
+		},
+		{
+			name:   "dotted key in plugin table",
+			config: "version = 2\n[plugins.\"io.containerd.grpc.v1.cri\"]\n  sandbox_image = \"registry.k8s.io/pause:3.9\"\n  containerd.default_runtime_name = \"kata\"\n",
+			want:   "kata",
+		},
+		{
+			name:   "fully dotted key",
+			config: "version = 3\nplugins.\"io.containerd.cri.v1.runtime\".containerd.default_runtime_name = \"runsc\"\n",
+			want:   Runsc,
+		},
+		{
+			name:   "commented out",
+			config: "version = 2\n[plugins.\"io.containerd.grpc.v1.cri\".containerd]\n  # default_runtime_name = \"kata\"\n",
+			want:   containerdDefaultRuntime,
+		},
+		{
+			name:   "unreadable line",
+			config: "version = 2\n# " + strings.Repeat("x", bufio.MaxScanTokenSize) + "\n",
+			want:   "",
+		},
+		{
+			name:   "unset",
+			config: "version = 2\n",
+			want:   containerdDefaultRuntime,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(configPath, []byte(tt.config), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+			if got := containerdDefaultHandler(configPath); got != tt.want {
+				t.Errorf("containerdDefaultHandler() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := containerdDefaultHandler(filepath.Join(t.TempDir(), "missing.toml")); got != "" {
+		t.Errorf("containerdDefaultHandler() without config = %q, want empty", got)
+	}
+}
+
+func TestContainerdDetector_DefaultHandler(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	config := "version = 2\n[plugins.\"io.containerd.grpc.v1.cri\".containerd]\n  default_runtime_name = \"crun\"\n"
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	socketPath := startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.0"}, nil)
+
+	detector := NewContainerdDetector(WithConfigPath(configPath))
+	detector.socketPaths = []string{socketPath}
+
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if got := runtimes[0].DefaultHandler; got != Crun {
+		t.Errorf("DefaultHandler = %q, want %q", got, Crun)
+	}
+}
+
+func TestContainerdDetector_EndpointConfigPath(t *testing.T) {
+	// Modifies HOME and the XDG directories, so can't run parallel
+	home := t.TempDir()
+	runtimeDir := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+
+	systemConfig := filepath.Join(t.TempDir(), "config.toml")
+	detector := NewContainerdDetector(WithConfigPath(systemConfig))
+
+	tests := []struct {
+		name   string
+		socket string
+		want   string
+	}{
+		{name: "system", socket: "/run/containerd/containerd.sock", want: systemConfig},
+		{name: "custom", socket: "/srv/containerd/containerd.sock", want: systemConfig},
+		{name: "k3s", socket: k3sContainerdSocket, want: k3sContainerdConfig},
+		{name: "rootless", socket: filepath.Join(runtimeDir, "containerd", "containerd.sock"), want: filepath.Join(home, "config", "containerd", "config.toml")},
+		{name: "lima", socket: filepath.Join(home, ".lima", "default", "sock", "containerd.sock"), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detector.endpointConfigPath(tt.socket); got != tt.want {
+				t.Errorf("endpointConfigPath(%q) = %q, want %q", tt.socket, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoDetectors is returned by DetectAll when the Detector was built without
+// any OCI, CRI, Podman, or Docker detector.
+var ErrNoDetectors = errors.New("no detectors configured")
+
+// DetectAll runs every configured detector and reports everything it learned:
+// the runtimes found, selected as Detect would, plus every detector error in
+// Warnings. Unlike Detect, failing detectors never turn into the returned
+// error, even when nothing is found, so diagnostics can show e.g. that the
+// containerd socket failed while runc was found.
+//
+// Selected is nil when no runtime is selectable, e.g. when only standalone
+// Wasm runtimes were found.
+//
+// Like DetectWithStrategy, the OTC_RUNTIME override does not restrict
+// detection, and WithStrictSelection ties are not enforced. Result hooks are
+// not run. Returns error only for a closed detector or ErrNoDetectors.
+func (d *Detector) DetectAll(ctx context.Context) (*Result, error) {
+	if d.closed.Load() {
+		return nil, ErrDetectorClosed
+	}
+	if d.oci == nil && d.cri == nil && d.podman == nil && d.docker == nil {
+		return nil, ErrNoDetectors
+	}
+
+	start := time.Now()
+	runtimes, warnings := d.collect(ctx)
+
+	result := NewResult(runtimes, warnings)
+	result.KubernetesNode = d.isKubernetesNode()
+	result.stamp(start)
+
+	return result, nil
+}
@@ -0,0 +1,108 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDetector_DetectAll(t *testing.T) {
+	t.Parallel()
+
+	criErr := errors.New("containerd socket not found")
+	ociErr := errors.New("no OCI runtimes found")
+
+	tests := []struct {
+		name         string
+		detector     *Detector
+		wantErr      error
+		wantRuntimes int
+		wantWarnings int
+		wantSelected string
+	}{
+		{
+			name: "runtimes alongside failing detector",
+			detector: NewDetector(
+				&fakeOCIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}},
+				&fakeCRIDetector{err: criErr},
+				nil,
+			),
+			wantRuntimes: 1,
+			wantWarnings: 1,
+			wantSelected: Runc,
+		},
+		{
+			name: "every detector failing",
+			detector: NewDetector(
+				&fakeOCIDetector{err: ociErr},
+				&fakeCRIDetector{err: criErr},
+				nil,
+			),
+			wantRuntimes: 0,
+			wantWarnings: 2,
+		},
+		{
+			name: "strict ties are not enforced",
+			detector: NewDetector(
+				&fakeOCIDetector{runtimes: []Runtime{
+					{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+					{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+				}},
+				nil,
+				nil,
+				WithStrictSelection(),
+			),
+			wantRuntimes: 2,
+			wantSelected: Runc,
+		},
+		{
+			name:     "no detectors",
+			detector: NewDetector(nil, nil, nil),
+			wantErr:  ErrNoDetectors,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.detector.override = ""
+			result, err := tt.detector.DetectAll(context.Background())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("DetectAll() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DetectAll() error = %v", err)
+			}
+
+			if result.RuntimeCount() != tt.wantRuntimes {
+				t.Errorf("RuntimeCount() = %d, want %d", result.RuntimeCount(), tt.wantRuntimes)
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %v, want %d", result.Warnings, tt.wantWarnings)
+			}
+			if tt.wantSelected == "" {
+				if result.Selected != nil {
+					t.Errorf("Selected = %v, want nil", result.Selected)
+				}
+			} else if result.Selected == nil || result.Selected.Name != tt.wantSelected {
+				t.Errorf("Selected = %v, want %s", result.Selected, tt.wantSelected)
+			}
+		})
+	}
+}
+
+func TestDetector_DetectAll_Closed(t *testing.T) {
+	t.Parallel()
+
+	detector := NewDetector(&fakeOCIDetector{}, nil, nil)
+	_ = detector.Close()
+
+	if _, err := detector.DetectAll(context.Background()); !errors.Is(err, ErrDetectorClosed) {
+		t.Errorf("DetectAll() error = %v, want ErrDetectorClosed", err)
+	}
+}
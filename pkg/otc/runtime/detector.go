@@ -1,6 +1,11 @@
 package runtime
 
-import "sort"
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
 
 // sortByPriority sorts runtimes by priority in descending order (highest first).
 // In case of equal priority, runtimes maintain their detection order (stable sort).
@@ -9,3 +14,81 @@ func sortByPriority(runtimes []Runtime) {
 		return runtimes[i].Priority > runtimes[j].Priority
 	})
 }
+
+// dedupByName collapses runtimes sharing a Name, e.g. a containerd binary
+// found on PATH and the containerd socket. The entry with the highest priority
+// is kept; on a tie a CRI runtime reached through its socket wins over one
+// found as a binary. The kept entry takes the position of the first
+// occurrence, so detection order is otherwise preserved.
+func dedupByName(runtimes []Runtime) []Runtime {
+	index := make(map[string]int, len(runtimes))
+	deduped := runtimes[:0:0]
+	for _, rt := range runtimes {
+		i, seen := index[rt.Name]
+		if !seen {
+			index[rt.Name] = len(deduped)
+			deduped = append(deduped, rt)
+			continue
+		}
+		if preferRuntime(rt, deduped[i]) {
+			deduped[i] = rt
+		}
+	}
+	return deduped
+}
+
+// preferRuntime reports whether a should replace b when deduplicating.
+func preferRuntime(a, b Runtime) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	return a.Type == TypeCRI && isSocketPath(a.Path) && !isSocketPath(b.Path)
+}
+
+// isSocketPath reports whether path names a socket endpoint rather than a binary.
+func isSocketPath(path string) bool {
+	return strings.HasPrefix(path, "unix://") || strings.HasSuffix(path, ".sock")
+}
+
+// applyPriorityOverrides replaces the Priority of each runtime whose name has
+// an entry in priorities. Names that weren't detected are ignored.
+func applyPriorityOverrides(runtimes []Runtime, priorities map[string]int) {
+	for i := range runtimes {
+		if p, ok := priorities[runtimes[i].Name]; ok {
+			runtimes[i].Priority = p
+		}
+	}
+}
+
+// filterByName splits runtimes into those whose name matches re and the names of those excluded.
+// The relative order of kept runtimes is preserved.
+func filterByName(runtimes []Runtime, re *regexp.Regexp) ([]Runtime, []string) {
+	var kept []Runtime
+	var excluded []string
+	for _, rt := range runtimes {
+		if re.MatchString(rt.Name) {
+			kept = append(kept, rt)
+		} else {
+			excluded = append(excluded, rt.Name)
+		}
+	}
+	return kept, excluded
+}
+
+// filterByMinVersion drops runtimes older than the minimum version configured
+// for their name, returning the kept runtimes and a description of each one
+// excluded. Runtimes whose version can't be compared are excluded too, since
+// they can't be shown to meet the minimum. Names without a minimum are kept.
+func filterByMinVersion(runtimes []Runtime, minVersions map[string]string) ([]Runtime, []string) {
+	var kept []Runtime
+	var excluded []string
+	for _, rt := range runtimes {
+		minVersion, ok := minVersions[rt.Name]
+		if !ok || rt.AtLeast(minVersion) {
+			kept = append(kept, rt)
+			continue
+		}
+		excluded = append(excluded, fmt.Sprintf("%s %s (< %s)", rt.Name, rt.Version, minVersion))
+	}
+	return kept, excluded
+}
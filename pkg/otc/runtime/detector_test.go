@@ -0,0 +1,858 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// closingCRIDetector is a fake CRI detector that tracks Close calls.
+type closingCRIDetector struct {
+	fakeCRIDetector
+	closes atomic.Int32
+	err    error
+}
+
+func (c *closingCRIDetector) Close() error {
+	c.closes.Add(1)
+	return c.err
+}
+
+func TestDetector_Close(t *testing.T) {
+	t.Parallel()
+
+	closeErr := errors.New("connection pool close failed")
+	cri := &closingCRIDetector{
+		fakeCRIDetector: fakeCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}},
+		err:             closeErr,
+	}
+	detector := NewDetector(&fakeOCIDetector{}, cri, nil)
+
+	// Concurrent detection racing with Close must be safe
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := detector.Detect(context.Background())
+			if err != nil && !errors.Is(err, ErrDetectorClosed) {
+				t.Errorf("Detect() unexpected error = %v", err)
+			}
+		}()
+	}
+
+	if err := detector.Close(); !errors.Is(err, closeErr) {
+		t.Errorf("Close() error = %v, want %v", err, closeErr)
+	}
+	wg.Wait()
+
+	// Second Close is a no-op returning the same result
+	if err := detector.Close(); !errors.Is(err, closeErr) {
+		t.Errorf("second Close() error = %v, want %v", err, closeErr)
+	}
+	if got := cri.closes.Load(); got != 1 {
+		t.Errorf("sub-detector closed %d times, want 1", got)
+	}
+
+	if _, err := detector.Detect(context.Background()); !errors.Is(err, ErrDetectorClosed) {
+		t.Errorf("Detect() after Close error = %v, want %v", err, ErrDetectorClosed)
+	}
+}
+
+func TestDetector_Detect_StrictSelection(t *testing.T) {
+	t.Parallel()
+
+	tied := &fakeOCIDetector{runtimes: []Runtime{
+		{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+		{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+	}}
+	containerd := &fakeCRIDetector{runtimes: []Runtime{
+		{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI},
+	}}
+
+	tests := []struct {
+		name    string
+		cri     CRIDetector
+		opts    []DetectorOption
+		want    string
+		wantErr error
+	}{
+		{
+			name: "tie selects first by default",
+			want: Runc,
+		},
+		{
+			name:    "tie is an error in strict mode",
+			opts:    []DetectorOption{WithStrictSelection()},
+			wantErr: ErrAmbiguousSelection,
+		},
+		{
+			name: "unique top priority in strict mode",
+			cri:  containerd,
+			opts: []DetectorOption{WithStrictSelection()},
+			want: Containerd,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector := &Detector{oci: tied, cri: tt.cri}
+			for _, opt := range tt.opts {
+				opt(detector)
+			}
+
+			result, err := detector.Detect(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Detect() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if !contains(err.Error(), "runc, crun") {
+					t.Errorf("error %q does not list candidates", err.Error())
+				}
+				return
+			}
+			if result.Selected == nil || result.Selected.Name != tt.want {
+				t.Errorf("Selected = %v, want %s", result.Selected, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetector_Detect_NameFilter(t *testing.T) {
+	t.Parallel()
+
+	oci := &fakeOCIDetector{runtimes: []Runtime{
+		{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+		{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+	}}
+	cri := &fakeCRIDetector{runtimes: []Runtime{
+		{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI},
+	}}
+
+	tests := []struct {
+		name         string
+		pattern      string
+		wantRuntimes []string
+		wantExcluded string
+	}{
+		{
+			name:         "matching prefix",
+			pattern:      `^c`,
+			wantRuntimes: []string{Containerd, Crun},
+			wantExcluded: "excluded runtimes: runc",
+		},
+		{
+			name:         "matching alternation",
+			pattern:      `^(runc|containerd)$`,
+			wantRuntimes: []string{Containerd, Runc},
+			wantExcluded: "excluded runtimes: crun",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector := &Detector{oci: oci, cri: cri, noHostChecks: true}
+			WithNameFilter(regexp.MustCompile(tt.pattern))(detector)
+
+			result, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			var names []string
+			for _, rt := range result.Runtimes {
+				names = append(names, rt.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantRuntimes) {
+				t.Errorf("Runtimes = %v, want %v", names, tt.wantRuntimes)
+			}
+
+			if len(result.Warnings) != 1 || !contains(result.Warnings[0].Error(), tt.wantExcluded) {
+				t.Errorf("Warnings = %v, want one containing %q", result.Warnings, tt.wantExcluded)
+			}
+		})
+	}
+
+	t.Run("non-matching pattern", func(t *testing.T) {
+		t.Parallel()
+
+		// With nothing left, the exclusion warning becomes the detection error
+		detector := &Detector{oci: oci, cri: cri}
+		WithNameFilter(regexp.MustCompile(`^kata`))(detector)
+
+		_, err := detector.Detect(context.Background())
+		if err == nil || !contains(err.Error(), "excluded runtimes: containerd, runc, crun") {
+			t.Errorf("Detect() error = %v, want exclusion error", err)
+		}
+	})
+}
+
+func TestDetector_Detect_KeepsRuntimesWithWarning(t *testing.T) {
+	t.Parallel()
+
+	warning := newWarning(SeverityLow, "kept runtimes with unparseable versions: runc")
+	detector := &Detector{
+		oci: &fakeOCIDetector{
+			runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Version: unknownVersion, Priority: PriorityOCI}},
+			err:      warning,
+		},
+		cri: &fakeCRIDetector{err: errors.New("no CRI socket found")},
+	}
+
+	result, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if result.Selected == nil || result.Selected.Name != Runc {
+		t.Fatalf("Selected = %+v, want runc", result.Selected)
+	}
+	if len(result.Warnings) != 2 || !errors.Is(result.Warnings[0], warning) {
+		t.Errorf("Warnings = %v, want the OCI warning and the CRI error", result.Warnings)
+	}
+}
+
+func TestDetector_Detect_ResultHook(t *testing.T) {
+	t.Parallel()
+
+	oci := &fakeOCIDetector{runtimes: []Runtime{
+		{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+		{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+	}}
+
+	t.Run("hooks mutate result in order", func(t *testing.T) {
+		t.Parallel()
+
+		var order []string
+		detector := NewDetector(oci, nil, nil,
+			WithResultHook(func(r *Result) error {
+				order = append(order, "annotate")
+				for i := range r.Runtimes {
+					r.Runtimes[i].Capabilities = map[string]string{"site": "lab"}
+				}
+				return nil
+			}),
+			WithResultHook(func(r *Result) error {
+				order = append(order, "filter")
+				r.Runtimes = r.Runtimes[1:]
+				r.Selected = &r.Runtimes[0]
+				return nil
+			}),
+		)
+		detector.override = ""
+
+		result, err := detector.Detect(context.Background())
+		if err != nil {
+			t.Fatalf("Detect() error = %v", err)
+		}
+		if !reflect.DeepEqual(order, []string{"annotate", "filter"}) {
+			t.Errorf("hook order = %v, want [annotate filter]", order)
+		}
+		if result.Selected.Name != Crun || result.Selected.Capabilities["site"] != "lab" {
+			t.Errorf("Selected = %+v, want annotated crun", result.Selected)
+		}
+	})
+
+	t.Run("hook error fails detection", func(t *testing.T) {
+		t.Parallel()
+
+		errRejected := errors.New("no approved runtime")
+		detector := NewDetector(oci, nil, nil, WithResultHook(func(*Result) error {
+			return errRejected
+		}))
+		detector.override = ""
+
+		result, err := detector.Detect(context.Background())
+		if !errors.Is(err, errRejected) || result != nil {
+			t.Errorf("Detect() = %v, %v; want nil, %v", result, err, errRejected)
+		}
+	})
+}
+
+func TestDetector_Detect_EmptyResults(t *testing.T) {
+	t.Parallel()
+
+	errNoSocket := errors.New("containerd socket not found")
+
+	tests := []struct {
+		name         string
+		detector     func() *Detector
+		wantErr      bool
+		wantWarnings int
+		wantMode     Mode
+	}{
+		{
+			name: "nothing found",
+			detector: func() *Detector {
+				return &Detector{
+					oci: &fakeOCIDetector{},
+					cri: &fakeCRIDetector{err: errNoSocket},
+				}
+			},
+			wantWarnings: 1,
+			wantMode:     ModeAuto,
+		},
+		{
+			name: "no detectors configured",
+			detector: func() *Detector {
+				return &Detector{}
+			},
+			wantWarnings: 0,
+			wantMode:     ModeAuto,
+		},
+		{
+			name: "override runtime missing",
+			detector: func() *Detector {
+				return &Detector{
+					oci:      &fakeOCIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI}}},
+					override: Crun,
+				}
+			},
+			wantWarnings: 1,
+			wantMode:     ModeOverride,
+		},
+		{
+			name: "override detector fails",
+			detector: func() *Detector {
+				return &Detector{
+					cri:      &fakeCRIDetector{err: errNoSocket},
+					override: Containerd,
+				}
+			},
+			wantWarnings: 1,
+			wantMode:     ModeOverride,
+		},
+		{
+			name: "invalid override stays fatal",
+			detector: func() *Detector {
+				return &Detector{override: "lxc"}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Default contract: nothing found with detector errors is an error
+			if d := tt.detector(); tt.wantWarnings > 0 {
+				if result, err := d.Detect(context.Background()); err == nil || result != nil {
+					t.Errorf("default Detect() = %v, %v; want nil result and error", result, err)
+				}
+			}
+
+			d := tt.detector()
+			WithEmptyResults()(d)
+
+			result, err := d.Detect(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result == nil {
+				t.Fatal("Detect() returned nil result")
+			}
+			if !result.IsEmpty() || result.Selected != nil {
+				t.Errorf("Detect() = %+v, want empty result", result)
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %v, want %d", result.Warnings, tt.wantWarnings)
+			}
+			if result.Mode != tt.wantMode {
+				t.Errorf("Mode = %q, want %q", result.Mode, tt.wantMode)
+			}
+		})
+	}
+}
+
+func TestResult_IsEmpty(t *testing.T) {
+	t.Parallel()
+
+	if !(&Result{}).IsEmpty() {
+		t.Error("IsEmpty() = false for a result without runtimes")
+	}
+	if NewResult([]Runtime{{Name: Runc, Type: TypeOCI}}, nil).IsEmpty() {
+		t.Error("IsEmpty() = true for a result with runtimes")
+	}
+}
+
+func TestDetector_Detect_DetectorOrder(t *testing.T) {
+	t.Parallel()
+
+	errOCI := errors.New("oci detector failed")
+	errCRI := errors.New("cri detector failed")
+
+	tests := []struct {
+		name         string
+		order        []Type
+		wantWarnings []error
+	}{
+		{
+			name:         "default order",
+			order:        nil,
+			wantWarnings: []error{errOCI, errCRI},
+		},
+		{
+			name:         "CRI first",
+			order:        []Type{TypeCRI, TypeOCI},
+			wantWarnings: []error{errCRI, errOCI},
+		},
+		{
+			name:         "partial order runs remaining types after",
+			order:        []Type{TypePodman, TypeCRI},
+			wantWarnings: []error{errCRI, errOCI},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector := NewDetector(
+				&fakeOCIDetector{err: errOCI},
+				&fakeCRIDetector{err: errCRI},
+				&fakePodmanDetector{runtimes: []Runtime{{Name: Podman, Type: TypePodman, Priority: PriorityPodman}}},
+				WithDetectorOrder(tt.order),
+			)
+			detector.override = ""
+
+			result, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if !reflect.DeepEqual(result.Warnings, tt.wantWarnings) {
+				t.Errorf("Warnings = %v, want %v", result.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestDetector_DetectorOrder_TieBreak(t *testing.T) {
+	t.Parallel()
+
+	detector := NewDetector(
+		&fakeOCIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}},
+		&fakeCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityOCI}}},
+		nil,
+		WithDetectorOrder([]Type{TypeCRI}),
+	)
+	detector.override = ""
+
+	result, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if result.Selected.Name != Containerd {
+		t.Errorf("Selected = %s, want containerd detected first", result.Selected.Name)
+	}
+}
+
+func TestDetector_Detect_OnRuntimeFound(t *testing.T) {
+	t.Parallel()
+
+	oci := &fakeOCIDetector{runtimes: []Runtime{
+		{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+		{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+	}}
+	cri := &fakeCRIDetector{runtimes: []Runtime{
+		{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI},
+	}}
+
+	tests := []struct {
+		name      string
+		override  string
+		filter    *regexp.Regexp
+		want      []string
+		wantCount int
+	}{
+		{name: "auto detection", override: "", want: []string{Runc, Crun, Containerd}, wantCount: 3},
+		{name: "override", override: Crun, want: []string{Runc, Crun}, wantCount: 1},
+		{name: "auto detection with name filter", filter: regexp.MustCompile(`^c`), want: []string{Runc, Crun, Containerd}, wantCount: 2},
+		{name: "override with name filter", override: "runc,crun", filter: regexp.MustCompile(`^c`), want: []string{Runc, Crun}, wantCount: 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var found []string
+			opts := []DetectorOption{WithoutHostChecks(), WithOnRuntimeFound(func(rt Runtime) {
+				found = append(found, rt.Name)
+			})}
+			if tt.filter != nil {
+				opts = append(opts, WithNameFilter(tt.filter))
+			}
+			detector := NewDetector(oci, cri, nil, opts...)
+			detector.override = tt.override
+
+			result, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			// The callback sees every detected runtime, before filtering
+			if !reflect.DeepEqual(found, tt.want) {
+				t.Errorf("callback saw %v, want %v", found, tt.want)
+			}
+			if result.RuntimeCount() != tt.wantCount {
+				t.Errorf("RuntimeCount() = %d, want %d", result.RuntimeCount(), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestDetector_WithCRITimeout(t *testing.T) {
+	t.Parallel()
+
+	containerd := NewContainerdDetector()
+	NewDetector(nil, containerd, nil, WithCRITimeout(500*time.Millisecond))
+	if containerd.timeout != 500*time.Millisecond || containerd.timeoutWarning != nil {
+		t.Errorf("containerd timeout = %v (warning %v), want 500ms", containerd.timeout, containerd.timeoutWarning)
+	}
+
+	crio := NewCRIODetector()
+	NewDetector(nil, crio, nil, WithCRITimeout(500*time.Millisecond))
+	if crio.timeout != 500*time.Millisecond || crio.timeoutWarning != nil {
+		t.Errorf("crio timeout = %v (warning %v), want 500ms", crio.timeout, crio.timeoutWarning)
+	}
+
+	// Detectors without the hook are left alone
+	NewDetector(nil, &fakeCRIDetector{}, nil, WithCRITimeout(time.Second))
+}
+
+func TestDetector_WithExtraSocketPaths(t *testing.T) {
+	t.Parallel()
+
+	defaults := append([]string(nil), containerdSocketPaths...)
+	extra := startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.13"}, nil)
+
+	cri := NewContainerdDetector()
+	cri.socketPaths = []string{"/nonexistent/containerd.sock"}
+	detector := NewDetector(nil, cri, nil, WithExtraSocketPaths(extra), WithOverride(""))
+
+	result, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if result.Selected.Path != extra {
+		t.Errorf("Selected.Path = %q, want extra socket %q", result.Selected.Path, extra)
+	}
+
+	NewDetector(nil, NewContainerdDetector(), nil, WithExtraSocketPaths(extra))
+	if !reflect.DeepEqual(containerdSocketPaths, defaults) {
+		t.Errorf("containerdSocketPaths = %v, want unchanged %v", containerdSocketPaths, defaults)
+	}
+}
+
+func TestDetector_WithOverride(t *testing.T) {
+	// Modifies OTC_RUNTIME, so can't run parallel
+	t.Setenv("OTC_RUNTIME", Runc)
+
+	oci := &fakeOCIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}}
+	cri := &fakeCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}}
+
+	tests := []struct {
+		name     string
+		opts     []DetectorOption
+		wantName string
+		wantMode Mode
+	}{
+		{name: "environment override", wantName: Runc, wantMode: ModeOverride},
+		{name: "option takes precedence", opts: []DetectorOption{WithOverride(Containerd)}, wantName: Containerd, wantMode: ModeOverride},
+		{name: "empty option forces auto", opts: []DetectorOption{WithOverride("")}, wantName: Containerd, wantMode: ModeAuto},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewDetector(oci, cri, nil, tt.opts...).Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if result.Selected.Name != tt.wantName || result.Mode != tt.wantMode {
+				t.Errorf("Detect() selected %s in mode %s, want %s in mode %s",
+					result.Selected.Name, result.Mode, tt.wantName, tt.wantMode)
+			}
+		})
+	}
+}
+
+func TestDetector_Detect_WithMinVersion(t *testing.T) {
+	t.Parallel()
+
+	oci := &fakeOCIDetector{runtimes: []Runtime{
+		{Name: Crun, Type: TypeOCI, Version: "1.7.2", Priority: PriorityOCI},
+		{Name: Runc, Type: TypeOCI, Version: "1.1.12", Priority: PriorityOCI},
+		{Name: Youki, Type: TypeOCI, Version: unknownVersion, Priority: PriorityOCI},
+	}}
+
+	tests := []struct {
+		name         string
+		opts         []DetectorOption
+		wantRuntimes []string
+		wantSelected string
+		wantWarnings int
+	}{
+		{
+			name:         "selected runtime too old",
+			opts:         []DetectorOption{WithMinVersion(Crun, "1.8")},
+			wantRuntimes: []string{Runc, Youki},
+			wantSelected: Runc,
+			wantWarnings: 1,
+		},
+		{
+			name:         "minimum met",
+			opts:         []DetectorOption{WithMinVersion(Crun, "1.7")},
+			wantRuntimes: []string{Crun, Runc, Youki},
+			wantSelected: Crun,
+		},
+		{
+			name:         "unparseable version excluded",
+			opts:         []DetectorOption{WithMinVersion(Youki, "0.3")},
+			wantRuntimes: []string{Crun, Runc},
+			wantSelected: Crun,
+			wantWarnings: 1,
+		},
+		{
+			name:         "minimum for undetected runtime",
+			opts:         []DetectorOption{WithMinVersion(Containerd, "2.0")},
+			wantRuntimes: []string{Crun, Runc, Youki},
+			wantSelected: Crun,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := &fakeOCIDetector{runtimes: append([]Runtime(nil), oci.runtimes...)}
+			detector := NewDetector(fake, nil, nil, tt.opts...)
+			detector.override = ""
+
+			result, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			var names []string
+			for _, rt := range result.Runtimes {
+				names = append(names, rt.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantRuntimes) {
+				t.Errorf("Runtimes = %v, want %v", names, tt.wantRuntimes)
+			}
+			if result.Selected == nil || result.Selected.Name != tt.wantSelected {
+				t.Errorf("Selected = %v, want %s", result.Selected, tt.wantSelected)
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %v, want %d", result.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestDetector_Detect_OverrideWithMinVersion(t *testing.T) {
+	t.Parallel()
+
+	oci := &fakeOCIDetector{runtimes: []Runtime{
+		{Name: Crun, Type: TypeOCI, Version: "1.7.2", Priority: PriorityOCI},
+		{Name: Runc, Type: TypeOCI, Version: "1.0.3", Priority: PriorityOCI},
+	}}
+
+	t.Run("overridden runtime too old", func(t *testing.T) {
+		t.Parallel()
+
+		detector := NewDetector(oci, nil, nil, WithOverride(Runc), WithMinVersion(Runc, "1.1.0"), WithoutHostChecks())
+		result, err := detector.Detect(context.Background())
+		if !errors.Is(err, ErrRuntimeNotFound) {
+			t.Fatalf("Detect() = %v, %v, want %v", result, err, ErrRuntimeNotFound)
+		}
+		if !contains(err.Error(), "runc 1.0.3 (< 1.1.0)") {
+			t.Errorf("error %q does not name the excluded runtime", err)
+		}
+	})
+
+	t.Run("list falls back past too old runtime", func(t *testing.T) {
+		t.Parallel()
+
+		detector := NewDetector(oci, nil, nil, WithOverride("runc,crun"), WithMinVersion(Runc, "1.1.0"), WithoutHostChecks())
+		result, err := detector.Detect(context.Background())
+		if err != nil {
+			t.Fatalf("Detect() error = %v", err)
+		}
+		if result.Selected.Name != Crun || result.RuntimeCount() != 1 {
+			t.Errorf("Runtimes = %v, want only %s", result.Runtimes, Crun)
+		}
+		if len(result.Warnings) != 1 || WarningSeverity(result.Warnings[0]) != SeverityLow {
+			t.Errorf("Warnings = %v, want one low-severity exclusion", result.Warnings)
+		}
+	})
+}
+
+func TestDetector_Detect_WithPriorityOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		priorities   map[string]int
+		wantOrder    []string
+		wantPriority int // Of the selected runtime
+	}{
+		{
+			name:         "podman preferred",
+			priorities:   map[string]int{Podman: PriorityCRI + 1},
+			wantOrder:    []string{Podman, Containerd, Runc},
+			wantPriority: PriorityCRI + 1,
+		},
+		{
+			name:         "undetected runtime ignored",
+			priorities:   map[string]int{Crun: 1000},
+			wantOrder:    []string{Containerd, Runc, Podman},
+			wantPriority: PriorityCRI,
+		},
+		{
+			name:         "no overrides",
+			priorities:   nil,
+			wantOrder:    []string{Containerd, Runc, Podman},
+			wantPriority: PriorityCRI,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector := NewDetector(
+				&fakeOCIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}},
+				&fakeCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}},
+				&fakeCRIDetector{runtimes: []Runtime{{Name: Podman, Type: TypePodman, Priority: PriorityPodman}}},
+				WithPriorityOverride(tt.priorities),
+			)
+			detector.override = ""
+
+			result, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			var order []string
+			for _, rt := range result.Runtimes {
+				order = append(order, rt.Name)
+			}
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("Runtimes = %v, want %v", order, tt.wantOrder)
+			}
+			if result.Selected.Priority != tt.wantPriority {
+				t.Errorf("Selected.Priority = %d, want %d", result.Selected.Priority, tt.wantPriority)
+			}
+		})
+	}
+}
+
+func TestDedupByName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		runtimes []Runtime
+		want     []Runtime
+	}{
+		{
+			name: "higher priority kept",
+			runtimes: []Runtime{
+				{Name: Containerd, Type: TypeOCI, Path: "/usr/bin/containerd", Priority: PriorityOCI},
+				{Name: Runc, Type: TypeOCI, Path: "/usr/bin/runc", Priority: PriorityOCI},
+				{Name: Containerd, Type: TypeCRI, Path: "/run/containerd/containerd.sock", Priority: PriorityCRI},
+			},
+			want: []Runtime{
+				{Name: Containerd, Type: TypeCRI, Path: "/run/containerd/containerd.sock", Priority: PriorityCRI},
+				{Name: Runc, Type: TypeOCI, Path: "/usr/bin/runc", Priority: PriorityOCI},
+			},
+		},
+		{
+			name: "socket preferred on tie",
+			runtimes: []Runtime{
+				{Name: Containerd, Type: TypeCRI, Path: "/usr/bin/containerd", Priority: PriorityCRI},
+				{Name: Containerd, Type: TypeCRI, Path: "unix:///run/containerd/containerd.sock", Priority: PriorityCRI},
+			},
+			want: []Runtime{
+				{Name: Containerd, Type: TypeCRI, Path: "unix:///run/containerd/containerd.sock", Priority: PriorityCRI},
+			},
+		},
+		{
+			name: "first kept on full tie",
+			runtimes: []Runtime{
+				{Name: Runc, Type: TypeOCI, Path: "/usr/local/bin/runc", Priority: PriorityOCI},
+				{Name: Runc, Type: TypeOCI, Path: "/usr/bin/runc", Priority: PriorityOCI},
+			},
+			want: []Runtime{
+				{Name: Runc, Type: TypeOCI, Path: "/usr/local/bin/runc", Priority: PriorityOCI},
+			},
+		},
+		{
+			name: "no duplicates",
+			runtimes: []Runtime{
+				{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+				{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+			},
+			want: []Runtime{
+				{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+				{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := dedupByName(tt.runtimes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dedupByName() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetector_Detect_DeduplicatesAcrossDetectors(t *testing.T) {
+	t.Parallel()
+
+	detector := NewDetector(
+		&fakeOCIDetector{runtimes: []Runtime{
+			{Name: Containerd, Type: TypeOCI, Path: "/usr/bin/containerd", Priority: PriorityOCI},
+			{Name: Runc, Type: TypeOCI, Path: "/usr/bin/runc", Priority: PriorityOCI},
+		}},
+		&fakeCRIDetector{runtimes: []Runtime{
+			{Name: Containerd, Type: TypeCRI, Path: "/run/containerd/containerd.sock", Priority: PriorityCRI},
+		}},
+		nil,
+	)
+	detector.override = ""
+
+	result, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if result.RuntimeCount() != 2 {
+		t.Fatalf("Runtimes = %+v, want containerd once plus runc", result.Runtimes)
+	}
+	if result.Selected.Path != "/run/containerd/containerd.sock" {
+		t.Errorf("Selected.Path = %q, want the containerd socket", result.Selected.Path)
+	}
+}
@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Nerdctl is the containerd CLI used by Lima and Rancher Desktop. It is reported
+// as the "client" capability of containerd runtimes found through it.
+const Nerdctl = "nerdctl"
+
+// limaContainerdSockets returns the containerd sockets Lima forwards from its
+// VMs to the host, one per instance (~/.lima/<instance>/sock/containerd.sock).
+// Returns nil if the home directory is unknown.
+func limaContainerdSockets() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	// Glob only fails for a malformed pattern, which this one is not
+	sockets, _ := filepath.Glob(filepath.Join(home, ".lima", "*", "sock", "containerd.sock"))
+	return sockets
+}
+
+// isLimaSocket reports whether path is under ~/.lima, where Lima forwards
+// sockets from its VMs.
+func isLimaSocket(path string) bool {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(filepath.Join(home, ".lima"), path)
+	return err == nil && !strings.HasPrefix(rel, "..")
+}
+
+// rancherDesktopDockerSocket returns the Docker socket Rancher Desktop exposes
+// in moby mode (~/.rd/docker.sock), or "" if the home directory is unknown.
+func rancherDesktopDockerSocket() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".rd", "docker.sock")
+}
+
+// nerdctlVersionOutput is the JSON printed by `nerdctl version --format '{{json .}}'`.
+type nerdctlVersionOutput struct {
+	Server *struct {
+		Components []struct {
+			Name    string `json:"Name"`
+			Version string `json:"Version"`
+		} `json:"Components"`
+	} `json:"Server"`
+}
+
+// nerdctlContainerdVersion asks nerdctl for the version of the containerd it
+// drives. On Lima and Rancher Desktop hosts nerdctl forwards into the VM, so
+// this finds containerd when none of its sockets exist on the host.
+// Returns the nerdctl path and containerd version, or error if nerdctl is
+// missing, fails, or reports no containerd server.
+func nerdctlContainerdVersion(ctx context.Context, run commandRunner) (path, version string, err error) {
+	path, err = exec.LookPath(Nerdctl)
+	if err != nil {
+		return "", "", fmt.Errorf("nerdctl not found in PATH: %w", err)
+	}
+
+	output, err := run(ctx, path, "version", "--format", "{{json .}}")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to execute nerdctl version: %w", err)
+	}
+
+	version, err = parseNerdctlVersion(output)
+	if err != nil {
+		return "", "", err
+	}
+	return path, version, nil
+}
+
+// parseNerdctlVersion extracts the containerd server version from nerdctl JSON output.
+func parseNerdctlVersion(output []byte) (string, error) {
+	var v nerdctlVersionOutput
+	if err := json.Unmarshal(output, &v); err != nil {
+		return "", fmt.Errorf("failed to parse nerdctl version output: %w", err)
+	}
+	if v.Server == nil {
+		return "", fmt.Errorf("nerdctl reported no server; containerd is not reachable")
+	}
+
+	for _, c := range v.Server.Components {
+		if strings.EqualFold(c.Name, Containerd) && c.Version != "" {
+			return c.Version, nil
+		}
+	}
+	return "", fmt.Errorf("nerdctl reported no containerd version")
+}
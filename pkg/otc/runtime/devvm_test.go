@@ -0,0 +1,182 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseNerdctlVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "containerd component",
+			output: `{"Client":{"Version":"v1.7.6"},"Server":{"Components":[{"Name":"containerd","Version":"v1.7.20"},{"Name":"runc","Version":"1.1.13"}]}}`,
+			want:   "v1.7.20",
+		},
+		{
+			name:    "server unreachable",
+			output:  `{"Client":{"Version":"v1.7.6"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "no containerd component",
+			output:  `{"Server":{"Components":[{"Name":"runc","Version":"1.1.13"}]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			output:  `nerdctl version v1.7.6`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseNerdctlVersion([]byte(tt.output))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNerdctlVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseNerdctlVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimaContainerdSockets(t *testing.T) {
+	// Modifies HOME, so can't run parallel
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	var want []string
+	for _, instance := range []string{"default", "k8s"} {
+		dir := filepath.Join(home, ".lima", instance, "sock")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create Lima dir: %v", err)
+		}
+		socket := filepath.Join(dir, "containerd.sock")
+		if err := os.WriteFile(socket, nil, 0600); err != nil {
+			t.Fatalf("failed to create socket placeholder: %v", err)
+		}
+		want = append(want, socket)
+	}
+	// An instance without a forwarded socket is skipped
+	if err := os.MkdirAll(filepath.Join(home, ".lima", "docker"), 0755); err != nil {
+		t.Fatalf("failed to create Lima dir: %v", err)
+	}
+
+	if got := limaContainerdSockets(); !reflect.DeepEqual(got, want) {
+		t.Errorf("limaContainerdSockets() = %v, want %v", got, want)
+	}
+	if got, want := rancherDesktopDockerSocket(), filepath.Join(home, ".rd", "docker.sock"); got != want {
+		t.Errorf("rancherDesktopDockerSocket() = %q, want %q", got, want)
+	}
+}
+
+func TestContainerdDetector_NerdctlFallback(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	tests := []struct {
+		name        string
+		script      string
+		wantVersion string
+		wantErr     bool
+	}{
+		{
+			name:        "nerdctl reaches containerd",
+			script:      `echo '{"Server":{"Components":[{"Name":"containerd","Version":"v1.7.20"}]}}'`,
+			wantVersion: "v1.7.20",
+		},
+		{
+			name:    "nerdctl without server",
+			script:  `echo '{"Client":{"Version":"v1.7.6"}}'`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binDir := t.TempDir()
+			t.Setenv("PATH", binDir)
+			path := writeFakeBinary(t, binDir, Nerdctl, tt.script)
+
+			detector := NewContainerdDetector()
+			detector.socketPaths = []string{filepath.Join(t.TempDir(), "containerd.sock")}
+
+			runtimes, err := detector.Detect(context.Background())
+			if tt.wantErr {
+				if !errors.Is(err, ErrRuntimeNotFound) {
+					t.Fatalf("Detect() error = %v, want wrapping ErrRuntimeNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			rt := runtimes[0]
+			if rt.Name != Containerd || rt.Type != TypeCRI || rt.Version != tt.wantVersion || rt.Path != path {
+				t.Errorf("Detect() = %+v, want containerd %s via %s", rt, tt.wantVersion, path)
+			}
+			if rt.Capabilities["client"] != Nerdctl {
+				t.Errorf("client capability = %q, want %q", rt.Capabilities["client"], Nerdctl)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_NerdctlFallbackWarnings(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	writeFakeBinary(t, binDir, Nerdctl, `echo '{"Server":{"Components":[{"Name":"containerd","Version":"v1.7.20"}]}}'`)
+
+	socket := filepath.Join(t.TempDir(), "containerd.sock")
+	if err := os.Symlink(filepath.Join(t.TempDir(), "missing.sock"), socket); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+	detector := NewContainerdDetector()
+	detector.socketPaths = []string{socket}
+
+	runtimes, err := detector.Detect(context.Background())
+	if len(runtimes) != 1 {
+		t.Fatalf("Detect() = %+v, %v, want containerd via nerdctl", runtimes, err)
+	}
+	if !isWarning(err) || !strings.Contains(err.Error(), "broken symlink") {
+		t.Errorf("Detect() error = %v, want the broken symlink warning", err)
+	}
+}
+
+func TestContainerdDetector_NerdctlTimeout(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	writeFakeBinary(t, binDir, Nerdctl, `exec sleep 10`)
+
+	detector := NewContainerdDetector(WithTimeout(100 * time.Millisecond))
+	detector.socketPaths = []string{filepath.Join(t.TempDir(), "containerd.sock")}
+
+	start := time.Now()
+	if _, err := detector.Detect(context.Background()); !errors.Is(err, ErrRuntimeNotFound) {
+		t.Errorf("Detect() error = %v, want wrapping ErrRuntimeNotFound", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Detect() took %v, want it bounded by the timeout", elapsed)
+	}
+}
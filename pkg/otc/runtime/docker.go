@@ -0,0 +1,116 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultDockerSocket is the rootful Docker Engine API socket
+const defaultDockerSocket = "/var/run/docker.sock"
+
+// dockerVersionURL is the Engine API version endpoint; the host is ignored on a Unix socket
+const dockerVersionURL = "http://d/version"
+
+// dockerDetector detects Docker via its Engine API socket
+type dockerDetector struct {
+	rootfulSocket string
+	timeout       time.Duration
+	logger        *slog.Logger // Debug tracing; nil logs nothing
+
+	// timeoutWarning reports an invalid OTC_DETECT_TIMEOUT with each detection
+	timeoutWarning error
+}
+
+// NewDockerDetector creates a Docker detector. When DOCKER_HOST is set to a
+// unix:// address, only that socket is checked, as the docker CLI does.
+// Otherwise it checks the rootful socket (/var/run/docker.sock), the
+// rootless one under $XDG_RUNTIME_DIR, then Rancher Desktop's ~/.rd/docker.sock.
+// The socket timeout is read from OTC_DETECT_TIMEOUT when set; an invalid
+// value falls back to the default and is reported as a warning by Detect.
+func NewDockerDetector() DockerDetector {
+	timeout, timeoutErr := getTimeoutFromEnv(defaultDetectTimeout)
+	return &dockerDetector{
+		rootfulSocket:  defaultDockerSocket,
+		timeout:        timeout,
+		timeoutWarning: timeoutErr,
+	}
+}
+
+// setLogger sets the logger used to trace socket queries.
+func (d *dockerDetector) setLogger(logger *slog.Logger) {
+	d.logger = logger
+}
+
+// Detect finds Docker by querying the Engine API version endpoint.
+func (d *dockerDetector) Detect(ctx context.Context) ([]Runtime, error) {
+	sockets, err := d.socketPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, socket := range sockets {
+		version, commit, err := d.socketVersion(ctx, socket)
+		loggerOrDiscard(d.logger).Debug("API version queried", "runtime", Docker, "path", socket, "version", version, "error", err)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return []Runtime{{
+			Name:     Docker,
+			Type:     TypeDocker,
+			Version:  version,
+			Path:     socket,
+			Priority: PriorityDocker,
+			Commit:   commit,
+			Rootless: isRootlessSocket(socket),
+		}}, d.timeoutWarning
+	}
+	return nil, fmt.Errorf("docker socket %w: %w", ErrRuntimeNotFound, errors.Join(errs...))
+}
+
+// socketPaths returns the DOCKER_HOST socket if set, otherwise the rootful
+// socket, the rootless one if XDG_RUNTIME_DIR is set, and Rancher Desktop's.
+// Returns error if DOCKER_HOST names a non-Unix address.
+func (d *dockerDetector) socketPaths() ([]string, error) {
+	if host := strings.TrimSpace(os.Getenv("DOCKER_HOST")); host != "" {
+		path, ok := strings.CutPrefix(host, schemeUnix+"://")
+		if !ok || path == "" {
+			return nil, fmt.Errorf("DOCKER_HOST=%s: only unix:// addresses are supported", host)
+		}
+		return []string{path}, nil
+	}
+
+	paths := []string{d.rootfulSocket}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "docker.sock"))
+	}
+	if rd := rancherDesktopDockerSocket(); rd != "" {
+		paths = append(paths, rd)
+	}
+	return paths, nil
+}
+
+// socketVersion queries the Engine API version endpoint on socket.
+func (d *dockerDetector) socketVersion(ctx context.Context, socket string) (version, commit string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	var body struct {
+		Version   string `json:"Version"`
+		GitCommit string `json:"GitCommit"`
+	}
+	if err := getSocketJSON(ctx, Docker, socket, dockerVersionURL, &body); err != nil {
+		return "", "", err
+	}
+	if body.Version == "" {
+		return "", "", fmt.Errorf("docker version response on %s has no version", socket)
+	}
+	return body.Version, body.GitCommit, nil
+}
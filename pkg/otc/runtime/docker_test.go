@@ -0,0 +1,162 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+// startFakeDockerAPI serves the Engine API version endpoint on a Unix socket at path.
+func startFakeDockerAPI(t *testing.T, path, version string) {
+	t.Helper()
+
+	serveSocketHTTP(t, path, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"Version":"` + version + `","ApiVersion":"1.47","GitCommit":"41ca978"}`))
+	})
+}
+
+func TestDockerDetector_Detect(t *testing.T) {
+	// Modifies DOCKER_HOST, XDG_RUNTIME_DIR, and HOME, so can't run parallel
+
+	tests := []struct {
+		name         string
+		setup        func(t *testing.T, rootful, runtimeDir string) (dockerHost string)
+		wantVersion  string
+		wantRootless bool
+		wantErr      bool
+	}{
+		{
+			name: "rootful socket",
+			setup: func(t *testing.T, rootful, _ string) string {
+				startFakeDockerAPI(t, rootful, "27.3.1")
+				return ""
+			},
+			wantVersion: "27.3.1",
+		},
+		{
+			name: "rootless socket",
+			setup: func(t *testing.T, _, runtimeDir string) string {
+				startFakeDockerAPI(t, filepath.Join(runtimeDir, "docker.sock"), "26.1.4")
+				return ""
+			},
+			wantVersion:  "26.1.4",
+			wantRootless: true,
+		},
+		{
+			name: "DOCKER_HOST socket",
+			setup: func(t *testing.T, rootful, _ string) string {
+				startFakeDockerAPI(t, rootful, "27.3.1")
+				custom := filepath.Join(t.TempDir(), "custom.sock")
+				startFakeDockerAPI(t, custom, "25.0.5")
+				return "unix://" + custom
+			},
+			wantVersion: "25.0.5",
+		},
+		{
+			name: "DOCKER_HOST over TCP",
+			setup: func(t *testing.T, rootful, _ string) string {
+				startFakeDockerAPI(t, rootful, "27.3.1")
+				return "tcp://127.0.0.1:2375"
+			},
+			wantErr: true,
+		},
+		{
+			name: "Rancher Desktop socket",
+			setup: func(t *testing.T, rootful, _ string) string {
+				startFakeDockerAPI(t, filepath.Join(filepath.Dir(filepath.Dir(rootful)), "home", ".rd", "docker.sock"), "26.1.5")
+				return ""
+			},
+			wantVersion: "26.1.5",
+		},
+		{
+			name:    "not installed",
+			setup:   func(_ *testing.T, _, _ string) string { return "" },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			rootful := filepath.Join(dir, "rootful", "docker.sock")
+			runtimeDir := filepath.Join(dir, "user")
+			t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+			t.Setenv("HOME", filepath.Join(dir, "home"))
+			t.Setenv("DOCKER_HOST", tt.setup(t, rootful, runtimeDir))
+
+			detector := NewDockerDetector().(*dockerDetector)
+			detector.rootfulSocket = rootful
+
+			runtimes, err := detector.Detect(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Detect() = %+v, want error", runtimes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			rt := runtimes[0]
+			if rt.Name != Docker || rt.Type != TypeDocker || rt.Priority != PriorityDocker {
+				t.Errorf("Detect() = %+v, want docker runtime", rt)
+			}
+			if rt.Version != tt.wantVersion {
+				t.Errorf("Version = %q, want %q", rt.Version, tt.wantVersion)
+			}
+			if rt.Commit != "41ca978" {
+				t.Errorf("Commit = %q, want %q", rt.Commit, "41ca978")
+			}
+			if rt.Rootless != tt.wantRootless {
+				t.Errorf("Rootless = %v, want %v", rt.Rootless, tt.wantRootless)
+			}
+		})
+	}
+}
+
+func TestDockerDetector_NotFoundWrapsSentinel(t *testing.T) {
+	// Modifies DOCKER_HOST, XDG_RUNTIME_DIR, and HOME, so can't run parallel
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	t.Setenv("HOME", t.TempDir())
+
+	detector := NewDockerDetector().(*dockerDetector)
+	detector.rootfulSocket = filepath.Join(t.TempDir(), "docker.sock")
+
+	if _, err := detector.Detect(context.Background()); !errors.Is(err, ErrRuntimeNotFound) {
+		t.Errorf("Detect() error = %v, want wrapping ErrRuntimeNotFound", err)
+	}
+}
+
+func TestDetector_Detect_OverrideDocker(t *testing.T) {
+	t.Parallel()
+
+	docker := &fakeCRIDetector{runtimes: []Runtime{{Name: Docker, Type: TypeDocker, Version: "27.3.1", Priority: PriorityDocker}}}
+	oci := &fakeOCIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}}
+
+	detector := NewDetector(oci, nil, nil, WithDockerDetector(docker), WithOverride(Docker))
+	result, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if result.Selected == nil || result.Selected.Name != Docker {
+		t.Errorf("Selected = %+v, want docker", result.Selected)
+	}
+
+	detector = NewDetector(oci, nil, nil, WithDockerDetector(docker))
+	detector.override = ""
+	result, err = detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(result.Runtimes) != 2 || result.Selected.Name != Runc {
+		t.Errorf("Detect() = %+v, want runc selected over docker", result.Runtimes)
+	}
+}
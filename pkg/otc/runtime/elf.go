@@ -0,0 +1,27 @@
+package runtime
+
+import (
+	"debug/elf"
+	"strconv"
+)
+
+// probeELF inspects a runtime binary's ELF headers for security-relevant build flags:
+//   - "pie": built as a position-independent executable (ELF type ET_DYN; runtime
+//     binaries are executables, so a dynamic object here means PIE)
+//   - "stripped": no .symtab symbol table
+//
+// Returns nil for files that aren't ELF (e.g. scripts or non-Linux binaries).
+func probeELF(path string) map[string]string {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return map[string]string{
+		"pie":      strconv.FormatBool(f.Type == elf.ET_DYN),
+		"stripped": strconv.FormatBool(f.Section(".symtab") == nil),
+	}
+}
@@ -0,0 +1,99 @@
+package runtime
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeELF writes a minimal little-endian ELF64 file of the given type,
+// optionally including a .symtab section, and returns its path.
+func writeELF(t *testing.T, typ elf.Type, withSymtab bool) string {
+	t.Helper()
+
+	const headerSize, sectionSize, symSize = 64, 64, 24
+
+	names := []byte("\x00.shstrtab\x00.symtab\x00")
+	sections := []elf.Section64{
+		{}, // Null section
+		{Name: 1, Type: uint32(elf.SHT_STRTAB), Off: headerSize, Size: uint64(len(names)), Addralign: 1},
+	}
+	dataEnd := uint64(headerSize + len(names))
+	if withSymtab {
+		sections = append(sections, elf.Section64{
+			Name: 11, Type: uint32(elf.SHT_SYMTAB), Off: dataEnd, Size: symSize,
+			Link: 1, Addralign: 8, Entsize: symSize,
+		})
+		dataEnd += symSize
+	}
+
+	var header elf.Header64
+	copy(header.Ident[:], elf.ELFMAG)
+	header.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	header.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	header.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+	header.Type = uint16(typ)
+	header.Machine = uint16(elf.EM_X86_64)
+	header.Version = uint32(elf.EV_CURRENT)
+	header.Shoff = dataEnd
+	header.Ehsize = headerSize
+	header.Shentsize = sectionSize
+	header.Shnum = uint16(len(sections))
+	header.Shstrndx = 1
+
+	var buf bytes.Buffer
+	for _, v := range []any{header, names, make([]byte, dataEnd-uint64(headerSize+len(names))), sections} {
+		if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+			t.Fatalf("failed to encode ELF: %v", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "runtime")
+	if err := os.WriteFile(path, buf.Bytes(), 0755); err != nil {
+		t.Fatalf("failed to write ELF: %v", err)
+	}
+	return path
+}
+
+func TestProbeELF(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T) string
+		want  map[string]string
+	}{
+		{
+			name:  "pie and stripped",
+			setup: func(t *testing.T) string { return writeELF(t, elf.ET_DYN, false) },
+			want:  map[string]string{"pie": "true", "stripped": "true"},
+		},
+		{
+			name:  "static executable with symbols",
+			setup: func(t *testing.T) string { return writeELF(t, elf.ET_EXEC, true) },
+			want:  map[string]string{"pie": "false", "stripped": "false"},
+		},
+		{
+			name: "not an elf file",
+			setup: func(t *testing.T) string {
+				return writeFakeBinary(t, t.TempDir(), "runc", "echo runc version 1.1.12")
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := probeELF(tt.setup(t)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("probeELF() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Endpoint schemes understood by the CRI detector
+const (
+	schemeUnix = "unix"
+	schemeSSH  = "ssh"
+)
+
+// defaultSSHPort is used when an ssh:// endpoint omits the port.
+const defaultSSHPort = "22"
+
+// Environment variables naming a CRI socket to try before the standard paths,
+// like DOCKER_HOST does for Docker.
+const (
+	containerdSocketEnv = "OTC_CONTAINERD_SOCKET"
+	crioSocketEnv       = "OTC_CRIO_SOCKET"
+)
+
+// SSHDialer opens a connection to a Unix socket on a remote host over SSH,
+// as used by ssh:// endpoints (e.g. "ssh://core@node1/run/containerd/containerd.sock").
+// Implementations typically authenticate with the SSH agent and forward the
+// socket with a "direct-streamlocal@openssh.com" channel.
+type SSHDialer interface {
+	// DialUnix connects to socketPath on addr ("host:port") as user.
+	DialUnix(ctx context.Context, user, addr, socketPath string) (net.Conn, error)
+}
+
+// endpoint is a parsed runtime socket address.
+type endpoint struct {
+	scheme string // schemeUnix or schemeSSH
+	user   string // SSH user; empty uses the dialer's default
+	addr   string // SSH "host:port"
+	path   string // Socket path, local or on the remote host
+}
+
+// remote reports whether the socket lives on another host.
+func (e endpoint) remote() bool {
+	return e.scheme == schemeSSH
+}
+
+// isRemote reports whether the runtime was reached through an ssh:// endpoint,
+// so probes of this host say nothing about it.
+func (r Runtime) isRemote() bool {
+	return strings.HasPrefix(r.Path, schemeSSH+"://")
+}
+
+// parseEndpoint parses a plain socket path, a unix:// URL, or an
+// ssh://[user@]host[:port]/path URL.
+func parseEndpoint(raw string) (endpoint, error) {
+	if strings.HasPrefix(raw, "/") {
+		return endpoint{scheme: schemeUnix, path: raw}, nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return endpoint{}, fmt.Errorf("invalid endpoint %q: %w", raw, err)
+	}
+
+	switch u.Scheme {
+	case schemeUnix:
+		if u.Path == "" {
+			return endpoint{}, fmt.Errorf("invalid endpoint %q: missing socket path", raw)
+		}
+		return endpoint{scheme: schemeUnix, path: u.Path}, nil
+
+	case schemeSSH:
+		if u.Hostname() == "" {
+			return endpoint{}, fmt.Errorf("invalid endpoint %q: missing host", raw)
+		}
+		if u.Path == "" || u.Path == "/" {
+			return endpoint{}, fmt.Errorf("invalid endpoint %q: missing socket path", raw)
+		}
+		port := u.Port()
+		if port == "" {
+			port = defaultSSHPort
+		}
+		return endpoint{
+			scheme: schemeSSH,
+			user:   u.User.Username(),
+			addr:   net.JoinHostPort(u.Hostname(), port),
+			path:   u.Path,
+		}, nil
+
+	default:
+		return endpoint{}, fmt.Errorf("invalid endpoint %q: unsupported scheme %q", raw, u.Scheme)
+	}
+}
+
+// getSocketFromEnv reads a socket path from the named environment variable.
+// Whitespace and an optional "unix://" prefix are trimmed.
+// Returns empty string if not set or if value is empty after trimming.
+func getSocketFromEnv(name string) string {
+	return strings.TrimPrefix(strings.TrimSpace(os.Getenv(name)), schemeUnix+"://")
+}
+
+// withEnvSocket returns paths with the socket named by the environment variable
+// moved to the front, so it is tried first. A set-but-missing socket is skipped
+// by the caller like any other path, falling through to the rest.
+func withEnvSocket(name string, paths []string) []string {
+	envPath := getSocketFromEnv(name)
+	if envPath == "" {
+		return paths
+	}
+
+	ordered := make([]string, 0, len(paths)+1)
+	ordered = append(ordered, envPath)
+	for _, path := range paths {
+		if path != envPath {
+			ordered = append(ordered, path)
+		}
+	}
+	return ordered
+}
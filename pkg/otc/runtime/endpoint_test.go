@@ -0,0 +1,218 @@
+package runtime
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    endpoint
+		wantErr bool
+	}{
+		{
+			name: "plain socket path",
+			raw:  "/run/containerd/containerd.sock",
+			want: endpoint{scheme: schemeUnix, path: "/run/containerd/containerd.sock"},
+		},
+		{
+			name: "unix url",
+			raw:  "unix:///run/containerd/containerd.sock",
+			want: endpoint{scheme: schemeUnix, path: "/run/containerd/containerd.sock"},
+		},
+		{
+			name: "ssh with user",
+			raw:  "ssh://core@node1/run/containerd/containerd.sock",
+			want: endpoint{scheme: schemeSSH, user: "core", addr: "node1:22", path: "/run/containerd/containerd.sock"},
+		},
+		{
+			name: "ssh with port and no user",
+			raw:  "ssh://node1:2222/run/user/1000/podman/podman.sock",
+			want: endpoint{scheme: schemeSSH, addr: "node1:2222", path: "/run/user/1000/podman/podman.sock"},
+		},
+		{
+			name: "ssh ipv6 host",
+			raw:  "ssh://root@[fd00::1]/run/containerd/containerd.sock",
+			want: endpoint{scheme: schemeSSH, user: "root", addr: "[fd00::1]:22", path: "/run/containerd/containerd.sock"},
+		},
+		{
+			name:    "ssh without socket path",
+			raw:     "ssh://core@node1",
+			wantErr: true,
+		},
+		{
+			name:    "ssh without host",
+			raw:     "ssh:///run/containerd/containerd.sock",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported scheme",
+			raw:     "tcp://node1:2375",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseEndpoint(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseEndpoint() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeSSHDialer forwards to a local Unix socket and records the requested target.
+type fakeSSHDialer struct {
+	local string
+	calls chan endpoint
+}
+
+// DialUnix connects to the local socket in place of the remote one.
+func (f *fakeSSHDialer) DialUnix(ctx context.Context, user, addr, socketPath string) (net.Conn, error) {
+	f.calls <- endpoint{scheme: schemeSSH, user: user, addr: addr, path: socketPath}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "unix", f.local)
+}
+
+func TestContainerdDetector_SSHEndpoint(t *testing.T) {
+	t.Parallel()
+
+	const raw = "ssh://core@node1/run/containerd/containerd.sock"
+	socketPath := startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.2"}, nil)
+
+	t.Run("forwards through SSH dialer", func(t *testing.T) {
+		t.Parallel()
+
+		ssh := &fakeSSHDialer{local: socketPath, calls: make(chan endpoint, 8)}
+		detector := NewContainerdDetector(WithEndpoint(raw), WithSSHDialer(ssh))
+
+		runtimes, err := detector.Detect(context.Background())
+		if err != nil {
+			t.Fatalf("Detect() error = %v", err)
+		}
+		if len(runtimes) != 1 || runtimes[0].Version != "1.7.2" || runtimes[0].Path != raw {
+			t.Fatalf("Detect() = %+v, want containerd 1.7.2 at %s", runtimes, raw)
+		}
+		if _, ok := runtimes[0].Capabilities["nvidiaReady"]; ok {
+			t.Error("host-local nvidiaReady probe ran for a remote endpoint")
+		}
+
+		want := endpoint{scheme: schemeSSH, user: "core", addr: "node1:22", path: "/run/containerd/containerd.sock"}
+		if got := <-ssh.calls; got != want {
+			t.Errorf("DialUnix() target = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("no SSH dialer", func(t *testing.T) {
+		t.Parallel()
+
+		detector := NewContainerdDetector(WithEndpoint(raw))
+		if _, err := detector.Detect(context.Background()); err == nil {
+			t.Error("Detect() expected error without an SSH dialer, got nil")
+		}
+	})
+
+	t.Run("local endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		detector := NewContainerdDetector(WithEndpoint("unix://" + socketPath))
+		runtimes, err := detector.Detect(context.Background())
+		if err != nil {
+			t.Fatalf("Detect() error = %v", err)
+		}
+		if runtimes[0].Version != "1.7.2" {
+			t.Errorf("Version = %q, want %q", runtimes[0].Version, "1.7.2")
+		}
+	})
+}
+
+func TestDetector_HostChecks_SkipsRemote(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	writeFakeBinary(t, binDir, "systemctl", `echo active`)
+	t.Setenv("PATH", binDir)
+
+	root := t.TempDir()
+	kubeletDir := filepath.Join(root, "var", "lib", "kubelet")
+	if err := os.MkdirAll(kubeletDir, 0755); err != nil {
+		t.Fatalf("failed to create kubelet dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(kubeletDir, "config.yaml"), []byte("kind: KubeletConfiguration\n"), 0644); err != nil {
+		t.Fatalf("failed to write kubelet config: %v", err)
+	}
+
+	runtimes := []Runtime{
+		{Name: Containerd, Type: TypeCRI, Path: "ssh://core@node1/run/containerd/containerd.sock"},
+		{Name: Containerd, Type: TypeCRI, Path: "/run/containerd/containerd.sock"},
+	}
+	detector := &Detector{kubernetes: kubernetesProbe{root: root}}
+	detector.hostChecks(context.Background(), runtimes)
+
+	for _, key := range []string{"serviceState", "imageGC"} {
+		if v, ok := runtimes[0].Capabilities[key]; ok {
+			t.Errorf("remote Capabilities[%q] = %q, want unset", key, v)
+		}
+		if _, ok := runtimes[1].Capabilities[key]; !ok {
+			t.Errorf("local Capabilities[%q] unset, want annotated", key)
+		}
+	}
+}
+
+func TestWithEnvSocket(t *testing.T) {
+	// Modifies OTC_CONTAINERD_SOCKET, so can't run parallel
+
+	defaults := []string{"/run/containerd/containerd.sock", "/var/run/containerd/containerd.sock"}
+
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{
+			name:  "unset",
+			value: "",
+			want:  defaults,
+		},
+		{
+			name:  "custom path tried first",
+			value: "/mnt/host/containerd.sock",
+			want:  []string{"/mnt/host/containerd.sock", "/run/containerd/containerd.sock", "/var/run/containerd/containerd.sock"},
+		},
+		{
+			name:  "whitespace and unix scheme trimmed",
+			value: "  unix:///mnt/host/containerd.sock\n",
+			want:  []string{"/mnt/host/containerd.sock", "/run/containerd/containerd.sock", "/var/run/containerd/containerd.sock"},
+		},
+		{
+			name:  "default path moved to front",
+			value: "/var/run/containerd/containerd.sock",
+			want:  []string{"/var/run/containerd/containerd.sock", "/run/containerd/containerd.sock"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(containerdSocketEnv, tt.value)
+
+			if got := withEnvSocket(containerdSocketEnv, defaults); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withEnvSocket() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrRuntimeNotFound reports that a runtime's binary or socket is absent.
+	// Detector errors and the OTC_RUNTIME not-found error wrap it.
+	ErrRuntimeNotFound = errors.New("not found")
+
+	// ErrDetectorNotConfigured is returned when OTC_RUNTIME names a runtime
+	// whose detector was passed to NewDetector as nil.
+	ErrDetectorNotConfigured = errors.New("detector not configured")
+
+	// ErrInvalidOverride is returned for an OTC_RUNTIME value that names an
+	// unknown or unsupported runtime.
+	ErrInvalidOverride = errors.New("invalid OTC_RUNTIME value")
+
+	// ErrRuntimeMismatch is returned when a CRI socket at one runtime's
+	// expected path answers as a different runtime.
+	ErrRuntimeMismatch = errors.New("belongs to another runtime")
+)
+
+// DetectionError reports that detecting a specific runtime failed.
+// Use errors.As to get the runtime name and errors.Is on the cause,
+// e.g. to tell ErrRuntimeNotFound from a runtime that exists but failed to answer.
+type DetectionError struct {
+	// Runtime is the name of the runtime being detected (e.g. "containerd")
+	Runtime string
+
+	// Err is the underlying cause
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DetectionError) Error() string {
+	return fmt.Sprintf("failed to detect runtime %s: %v", e.Runtime, e.Err)
+}
+
+// Unwrap returns the underlying cause.
+func (e *DetectionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,102 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetector_Detect_ErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	criErr := errors.New("connection refused")
+
+	tests := []struct {
+		name        string
+		detector    *Detector
+		override    string
+		wantIs      error
+		wantRuntime string // If set, the error must be a *DetectionError for this runtime
+	}{
+		{
+			name:     "detector not configured",
+			detector: NewDetector(nil, nil, nil),
+			override: Containerd,
+			wantIs:   ErrDetectorNotConfigured,
+		},
+		{
+			name:     "invalid override",
+			detector: NewDetector(nil, nil, nil),
+			override: "rkt",
+			wantIs:   ErrInvalidOverride,
+		},
+		{
+			name:     "docker detector not configured",
+			detector: NewDetector(nil, nil, nil),
+			override: Docker,
+			wantIs:   ErrDetectorNotConfigured,
+		},
+		{
+			name:     "runtime missing from results",
+			detector: NewDetector(&fakeOCIDetector{}, nil, nil),
+			override: Runc,
+			wantIs:   ErrRuntimeNotFound,
+		},
+		{
+			name:        "detector failure",
+			detector:    NewDetector(nil, &fakeCRIDetector{err: criErr}, nil),
+			override:    Containerd,
+			wantIs:      criErr,
+			wantRuntime: Containerd,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.detector.override = tt.override
+			_, err := tt.detector.Detect(context.Background())
+			if !errors.Is(err, tt.wantIs) {
+				t.Fatalf("Detect() error = %v, want errors.Is %v", err, tt.wantIs)
+			}
+
+			if tt.wantRuntime == "" {
+				return
+			}
+			var detErr *DetectionError
+			if !errors.As(err, &detErr) || detErr.Runtime != tt.wantRuntime {
+				t.Errorf("Detect() error = %v, want *DetectionError for %s", err, tt.wantRuntime)
+			}
+		})
+	}
+}
+
+func TestValidateOverride_ErrInvalidOverride(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"rkt", "Runc"} {
+		if err := ValidateOverride(value); !errors.Is(err, ErrInvalidOverride) {
+			t.Errorf("ValidateOverride(%q) = %v, want ErrInvalidOverride", value, err)
+		}
+	}
+}
+
+func TestCRIDetectors_SocketNotFound(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "missing.sock")
+
+	containerd := &ContainerdDetector{socketPaths: []string{missing}}
+	if _, err := containerd.Detect(context.Background()); !errors.Is(err, ErrRuntimeNotFound) {
+		t.Errorf("containerd Detect() error = %v, want ErrRuntimeNotFound", err)
+	}
+
+	crio := NewCRIODetector()
+	crio.socketPaths = []string{missing}
+	if _, err := crio.Detect(context.Background()); !errors.Is(err, ErrRuntimeNotFound) {
+		t.Errorf("crio Detect() error = %v, want ErrRuntimeNotFound", err)
+	}
+}
@@ -0,0 +1,61 @@
+package runtime
+
+import "strings"
+
+// EnvExports returns shell-safe KEY=VALUE assignments describing the result,
+// suitable for `eval "$(otc env)"`. Values are single-quoted.
+//
+// Emitted variables:
+//   - OTC_SELECTED_RUNTIME, OTC_SELECTED_TYPE, OTC_SELECTED_VERSION, OTC_SELECTED_PATH
+//     for the selected runtime (omitted if none)
+//   - OTC_<NAME>_SOCKET for each socket-based runtime, OTC_<NAME>_PATH for each binary
+func (r *Result) EnvExports() []string {
+	var exports []string
+
+	if r.Selected != nil {
+		exports = append(exports,
+			envAssign("OTC_SELECTED_RUNTIME", r.Selected.Name),
+			envAssign("OTC_SELECTED_TYPE", string(r.Selected.Type)),
+			envAssign("OTC_SELECTED_VERSION", r.Selected.Version),
+			envAssign("OTC_SELECTED_PATH", r.Selected.Path),
+		)
+	}
+
+	seen := make(map[string]bool)
+	for _, rt := range r.Runtimes {
+		suffix := "_SOCKET"
+		if rt.Type == TypeOCI || rt.Type == TypeWasm {
+			suffix = "_PATH"
+		}
+
+		key := "OTC_" + envName(rt.Name) + suffix
+		if seen[key] {
+			continue // Keep the highest priority entry
+		}
+		seen[key] = true
+		exports = append(exports, envAssign(key, rt.Path))
+	}
+
+	return exports
+}
+
+// envAssign formats a single-quoted shell assignment.
+// Embedded single quotes close the quoting, add an escaped quote, and reopen it.
+func envAssign(key, value string) string {
+	return key + "='" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// envName converts a runtime name into an environment variable component,
+// uppercasing letters and replacing anything else but digits with underscores.
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
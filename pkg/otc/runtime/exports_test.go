@@ -0,0 +1,52 @@
+package runtime
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResult_EnvExports(t *testing.T) {
+	t.Parallel()
+
+	runtimes := []Runtime{
+		{Name: Containerd, Type: TypeCRI, Version: "1.7.0", Path: "/run/containerd/containerd.sock", Priority: PriorityCRI},
+		{Name: Runc, Type: TypeOCI, Version: "1.1.12", Path: "/usr/bin/runc", Priority: PriorityOCI},
+		{Name: "crun-vm", Type: TypeOCI, Version: "0.2.0", Path: "/opt/it's here/crun-vm", Priority: PriorityOCI},
+	}
+
+	tests := []struct {
+		name   string
+		result *Result
+		want   []string
+	}{
+		{
+			name:   "selected runtime and paths",
+			result: &Result{Runtimes: runtimes, Selected: &runtimes[0]},
+			want: []string{
+				"OTC_SELECTED_RUNTIME='containerd'",
+				"OTC_SELECTED_TYPE='cri'",
+				"OTC_SELECTED_VERSION='1.7.0'",
+				"OTC_SELECTED_PATH='/run/containerd/containerd.sock'",
+				"OTC_CONTAINERD_SOCKET='/run/containerd/containerd.sock'",
+				"OTC_RUNC_PATH='/usr/bin/runc'",
+				`OTC_CRUN_VM_PATH='/opt/it'\''s here/crun-vm'`,
+			},
+		},
+		{
+			name:   "empty result",
+			result: &Result{},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.result.EnvExports(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EnvExports() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
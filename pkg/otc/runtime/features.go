@@ -0,0 +1,208 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ociFeatures is the subset of `<runtime> features` output used by capability probes.
+// See https://github.com/opencontainers/runtime-spec/blob/main/features.md
+type ociFeatures struct {
+	OCIVersionMin string            `json:"ociVersionMin"`
+	OCIVersionMax string            `json:"ociVersionMax"`
+	Linux         *linuxFeatures    `json:"linux"`
+	Annotations   map[string]string `json:"annotations"`
+	MountOptions  []string          `json:"mountOptions"`
+}
+
+// linuxFeatures describes the Linux-specific section of the features document.
+type linuxFeatures struct {
+	IntelRdt *featureToggle `json:"intelRdt"`
+	Apparmor *featureToggle `json:"apparmor"`
+}
+
+// featureToggle is the common {"enabled": bool} shape used by feature objects.
+type featureToggle struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// probeFeatures executes `<runtime> features` and converts the output into capabilities.
+// Runtimes that predate the features subcommand return an error.
+func probeFeatures(ctx context.Context, run commandRunner, path string) (map[string]string, error) {
+	output, err := run(ctx, path, "features")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute %s features: %w", path, err)
+	}
+	return parseFeatures(output)
+}
+
+// parseFeatures extracts capabilities from a features JSON document.
+// Reports the supported OCI spec range and whether OCI 1.1 features are available,
+// plus the default masked and readonly paths when the runtime annotates them.
+func parseFeatures(output []byte) (map[string]string, error) {
+	var f ociFeatures
+	if err := json.Unmarshal(output, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse features output: %w", err)
+	}
+
+	caps := make(map[string]string)
+
+	if f.OCIVersionMin != "" {
+		caps["ociVersionMin"] = f.OCIVersionMin
+	}
+
+	if f.OCIVersionMax != "" {
+		caps["ociVersionMax"] = f.OCIVersionMax
+		caps["oci1.1"] = strconv.FormatBool(compareSpecVersions(f.OCIVersionMax, "1.1.0") >= 0)
+	}
+
+	if f.Linux != nil && f.Linux.IntelRdt != nil && f.Linux.IntelRdt.Enabled != nil {
+		caps["intelRdt"] = strconv.FormatBool(*f.Linux.IntelRdt.Enabled)
+	}
+
+	if f.Linux != nil && f.Linux.Apparmor != nil && f.Linux.Apparmor.Enabled != nil {
+		caps["apparmor"] = strconv.FormatBool(*f.Linux.Apparmor.Enabled)
+	}
+
+	// Recursive read-only mounts also need kernel support, checked by the caller
+	if len(f.MountOptions) > 0 {
+		caps["rroMountOption"] = strconv.FormatBool(slices.Contains(f.MountOptions, "rro"))
+	}
+
+	// Default masked/readonly paths are security posture; only some runtimes annotate them
+	for _, name := range []string{"maskedPaths", "readonlyPaths"} {
+		if paths := annotatedPaths(f.Annotations, name); len(paths) > 0 {
+			caps[name] = strings.Join(paths, ",")
+		}
+	}
+
+	return caps, nil
+}
+
+// annotatedPaths returns the path list from the features annotation whose key
+// ends in "."+name (e.g. "org.example.runtime.maskedPaths"). Values may be a
+// JSON array or a comma-separated list. Returns nil when no such annotation exists.
+func annotatedPaths(annotations map[string]string, name string) []string {
+	var keys []string
+	for key := range annotations {
+		if key == name || strings.HasSuffix(key, "."+name) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys) // Deterministic choice if a runtime sets several
+	value := strings.TrimSpace(annotations[keys[0]])
+
+	var paths []string
+	if err := json.Unmarshal([]byte(value), &paths); err == nil {
+		return paths
+	}
+	for _, path := range strings.Split(value, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// compareSpecVersions compares the major.minor.patch core of two OCI spec versions.
+// Pre-release and build suffixes are ignored, so "1.1.0-rc.1" equals "1.1.0".
+// Missing or non-numeric components count as zero.
+func compareSpecVersions(a, b string) int {
+	pa, pb := specVersionCore(a), specVersionCore(b)
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// specVersionCore parses the numeric major.minor.patch components of a version.
+func specVersionCore(v string) [3]int {
+	var core [3]int
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	for i, part := range strings.SplitN(v, ".", 3) {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		core[i] = n
+	}
+	return core
+}
+
+// annotateInterchangeable records, for each OCI runtime reporting a spec range,
+// which other detected OCI runtimes can replace it. When a detected CRI runtime
+// reports the spec version it generates (SpecVersion), two runtimes are
+// interchangeable when both ranges contain that version. Otherwise their
+// ranges need only overlap, as a caller generating a spec within the overlap
+// can switch between them.
+// The list is stored comma-separated in Capabilities["interchangeableWith"].
+func annotateInterchangeable(runtimes []Runtime) {
+	generated := generatedSpecVersion(runtimes)
+	for i := range runtimes {
+		a := runtimes[i]
+		if a.Type != TypeOCI || !hasSpecRange(a) {
+			continue
+		}
+		if generated != "" && !specRangeContains(a, generated) {
+			continue
+		}
+
+		var peers []string
+		for j, b := range runtimes {
+			if i == j || b.Type != TypeOCI || !hasSpecRange(b) {
+				continue
+			}
+			if generated != "" && specRangeContains(b, generated) ||
+				generated == "" && specRangesOverlap(a, b) {
+				peers = append(peers, b.Name)
+			}
+		}
+
+		if len(peers) > 0 {
+			a.Capabilities["interchangeableWith"] = strings.Join(peers, ",")
+		}
+	}
+}
+
+// generatedSpecVersion returns the spec version generated by the first CRI
+// runtime that reports one, or "" if none does.
+func generatedSpecVersion(runtimes []Runtime) string {
+	for _, rt := range runtimes {
+		if rt.Type == TypeCRI && rt.SpecVersion != "" {
+			return rt.SpecVersion
+		}
+	}
+	return ""
+}
+
+// hasSpecRange reports whether a runtime advertised its supported OCI spec range.
+func hasSpecRange(rt Runtime) bool {
+	return rt.Capabilities["ociVersionMin"] != "" && rt.Capabilities["ociVersionMax"] != ""
+}
+
+// specRangeContains reports whether version lies within a runtime's supported spec range.
+func specRangeContains(rt Runtime, version string) bool {
+	return compareSpecVersions(rt.Capabilities["ociVersionMin"], version) <= 0 &&
+		compareSpecVersions(version, rt.Capabilities["ociVersionMax"]) <= 0
+}
+
+// specRangesOverlap reports whether two runtimes share at least one supported spec version.
+func specRangesOverlap(a, b Runtime) bool {
+	return compareSpecVersions(a.Capabilities["ociVersionMin"], b.Capabilities["ociVersionMax"]) <= 0 &&
+		compareSpecVersions(b.Capabilities["ociVersionMin"], a.Capabilities["ociVersionMax"]) <= 0
+}
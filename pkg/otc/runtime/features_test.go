@@ -0,0 +1,208 @@
+package runtime
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFeatures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		output  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "runc 1.1 features",
+			output: `{
+				"ociVersionMin": "1.0.0",
+				"ociVersionMax": "1.0.2-dev",
+				"hooks": ["prestart", "createRuntime", "poststop"],
+				"mountOptions": ["ro", "rw", "bind"],
+				"linux": {
+					"namespaces": ["cgroup", "ipc", "mount", "network", "pid", "user", "uts"],
+					"cgroup": {"v1": true, "v2": true, "systemd": true}
+				}
+			}`,
+			want: map[string]string{
+				"ociVersionMin":  "1.0.0",
+				"ociVersionMax":  "1.0.2-dev",
+				"oci1.1":         "false",
+				"rroMountOption": "false",
+			},
+		},
+		{
+			name: "runc 1.2 features",
+			output: `{
+				"ociVersionMin": "1.0.0",
+				"ociVersionMax": "1.2.0",
+				"linux": {
+					"intelRdt": {"enabled": true},
+					"apparmor": {"enabled": true}
+				}
+			}`,
+			want: map[string]string{
+				"ociVersionMin": "1.0.0",
+				"ociVersionMax": "1.2.0",
+				"oci1.1":        "true",
+				"intelRdt":      "true",
+				"apparmor":      "true",
+			},
+		},
+		{
+			name: "masked paths as json array",
+			output: `{
+				"ociVersionMax": "1.2.0",
+				"annotations": {
+					"io.github.seccomp.libseccomp.version": "2.5.4",
+					"org.example.runtime.maskedPaths": "[\"/proc/kcore\", \"/proc/keys\", \"/sys/firmware\"]"
+				}
+			}`,
+			want: map[string]string{
+				"ociVersionMax": "1.2.0",
+				"oci1.1":        "true",
+				"maskedPaths":   "/proc/kcore,/proc/keys,/sys/firmware",
+			},
+		},
+		{
+			name: "masked and readonly paths as comma lists",
+			output: `{
+				"annotations": {
+					"org.example.runtime.maskedPaths": "/proc/kcore, /proc/timer_list",
+					"org.example.runtime.readonlyPaths": "/proc/bus,/proc/sys"
+				}
+			}`,
+			want: map[string]string{
+				"maskedPaths":   "/proc/kcore,/proc/timer_list",
+				"readonlyPaths": "/proc/bus,/proc/sys",
+			},
+		},
+		{
+			name:   "empty document",
+			output: `{}`,
+			want:   map[string]string{},
+		},
+		{
+			name:    "not json",
+			output:  "unknown command \"features\"",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseFeatures([]byte(tt.output))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFeatures() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFeatures() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSpecVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.1.0", b: "1.1.0", want: 0},
+		{a: "1.2.0", b: "1.1.0", want: 1},
+		{a: "1.0.2-dev", b: "1.1.0", want: -1},
+		{a: "1.1.0-rc.1", b: "1.1.0", want: 0},
+		{a: "1.1", b: "1.1.0", want: 0},
+		{a: "", b: "1.0.0", want: -1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			if got := compareSpecVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareSpecVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotateInterchangeable(t *testing.T) {
+	t.Parallel()
+
+	specRange := func(min, max string) map[string]string {
+		return map[string]string{"ociVersionMin": min, "ociVersionMax": max}
+	}
+
+	tests := []struct {
+		name     string
+		runtimes []Runtime
+		want     map[string]string
+	}{
+		{
+			name: "no CRI spec version uses overlap",
+			runtimes: []Runtime{
+				{Name: Runc, Type: TypeOCI, Capabilities: specRange("1.0.0", "1.2.0")},
+				{Name: Crun, Type: TypeOCI, Capabilities: specRange("1.1.0", "1.2.0")},
+				{Name: Youki, Type: TypeOCI, Capabilities: specRange("0.9.0", "0.9.9")},
+				{Name: Runsc, Type: TypeOCI, Capabilities: map[string]string{}},
+				{Name: Containerd, Type: TypeCRI, Capabilities: specRange("1.0.0", "1.2.0")},
+			},
+			want: map[string]string{Runc: Crun, Crun: Runc, Youki: "", Runsc: "", Containerd: ""},
+		},
+		{
+			name: "containerd spec version must be in both ranges",
+			runtimes: []Runtime{
+				{Name: Runc, Type: TypeOCI, Capabilities: specRange("1.0.0", "1.2.0")},
+				{Name: Crun, Type: TypeOCI, Capabilities: specRange("1.1.0", "1.2.0")},
+				{Name: Youki, Type: TypeOCI, Capabilities: specRange("1.0.0", "1.0.2")},
+				{Name: Containerd, Type: TypeCRI, SpecVersion: "1.0.2-dev", Capabilities: map[string]string{}},
+			},
+			// crun overlaps runc but can't run the 1.0.2 specs containerd generates
+			want: map[string]string{Runc: Youki, Crun: "", Youki: Runc, Containerd: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			annotateInterchangeable(tt.runtimes)
+
+			for _, rt := range tt.runtimes {
+				if got := rt.Capabilities["interchangeableWith"]; got != tt.want[rt.Name] {
+					t.Errorf("%s interchangeableWith = %q, want %q", rt.Name, got, tt.want[rt.Name])
+				}
+			}
+		})
+	}
+}
+
+func TestContainerdSpecVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "1.6.24", want: "1.0.2-dev"},
+		{version: "v1.7.13", want: "1.1.0"},
+		{version: "2.0.0-rc.1", want: "1.2.0"},
+		{version: "1.5.0", want: ""},
+		{version: "unknown", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := containerdSpecVersion(tt.version); got != tt.want {
+			t.Errorf("containerdSpecVersion(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+)
+
+// firstDetectorOrder is the priority-class order DetectFirst probes detectors in.
+var firstDetectorOrder = []Type{TypeCRI, TypeOCI, TypePodman, TypeDocker}
+
+// DetectFirst returns a runtime as quickly as possible, for callers that need
+// some runtime to use and don't care about alternatives. Detectors are probed
+// in priority-class order (CRI, OCI, Podman, then Docker), and probing stops at the
+// first detector that finds a runtime; the highest priority runtime it found is
+// returned. Standalone Wasm runtimes are never returned. WithNameFilter,
+// WithMinVersion, and WithPriorityOverride apply, but the health checks that
+// Detect reports as warnings are skipped and result hooks are not run.
+//
+// If OTC_RUNTIME is set, only that runtime is probed, as with Detect.
+// Returns error if no runtime is found: the first detector error if any
+// detector failed, otherwise one wrapping ErrRuntimeNotFound.
+func (d *Detector) DetectFirst(ctx context.Context) (*Runtime, error) {
+	if d.closed.Load() {
+		return nil, ErrDetectorClosed
+	}
+
+	if d.override != "" {
+		result, err := d.detectOverride(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if result.Selected == nil {
+			// WithEmptyResults turned the not-found error into a warning
+			return nil, result.Warnings[0]
+		}
+		return result.Selected, nil
+	}
+
+	if d.oci == nil && d.cri == nil && d.podman == nil && d.docker == nil {
+		return nil, ErrNoDetectors
+	}
+
+	var firstErr error
+	for _, typ := range firstDetectorOrder {
+		detect := d.detectorFor(typ)
+		if detect == nil {
+			continue
+		}
+
+		found, err := d.runDetector(ctx, typ, detect)
+		if err != nil && !isWarning(err) {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		d.notifyFound(found)
+
+		if rt := d.pickFirst(found); rt != nil {
+			return rt, nil
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return nil, fmt.Errorf("container runtime %w", ErrRuntimeNotFound)
+}
+
+// pickFirst applies the priority overrides and filters from collect to one
+// detector's runtimes and returns the highest priority survivor, skipping
+// standalone Wasm runtimes, or nil.
+func (d *Detector) pickFirst(runtimes []Runtime) *Runtime {
+	runtimes, _ = d.applyFilters(dedupByName(runtimes))
+	return SelectHighestPriority(runtimes)
+}
@@ -0,0 +1,137 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestDetector_DetectFirst(t *testing.T) {
+	t.Parallel()
+
+	criErr := errors.New("containerd socket not found")
+
+	tests := []struct {
+		name          string
+		cri           *countingCRIDetector
+		oci           *countingCRIDetector
+		opts          []DetectorOption
+		want          string
+		wantErr       error
+		wantOCICalled bool
+	}{
+		{
+			name: "CRI found skips OCI",
+			cri:  &countingCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}},
+			oci:  &countingCRIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}},
+			want: Containerd,
+		},
+		{
+			name:          "CRI failing falls through to OCI",
+			cri:           &countingCRIDetector{err: criErr},
+			oci:           &countingCRIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}, {Name: Crun, Type: TypeOCI, Priority: PriorityOCI + 1}}},
+			want:          Crun,
+			wantOCICalled: true,
+		},
+		{
+			name:          "name filter excludes CRI",
+			cri:           &countingCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}},
+			oci:           &countingCRIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}},
+			opts:          []DetectorOption{WithNameFilter(regexp.MustCompile(`^runc$`))},
+			want:          Runc,
+			wantOCICalled: true,
+		},
+		{
+			name:          "wasmedge is never returned",
+			cri:           &countingCRIDetector{},
+			oci:           &countingCRIDetector{runtimes: []Runtime{{Name: WasmEdge, Type: TypeWasm, Priority: PriorityWasm}}},
+			wantErr:       ErrRuntimeNotFound,
+			wantOCICalled: true,
+		},
+		{
+			name:          "every detector failing returns first error",
+			cri:           &countingCRIDetector{err: criErr},
+			oci:           &countingCRIDetector{err: errors.New("no OCI runtimes found")},
+			wantErr:       criErr,
+			wantOCICalled: true,
+		},
+		{
+			name:          "nothing found",
+			cri:           &countingCRIDetector{},
+			oci:           &countingCRIDetector{},
+			wantErr:       ErrRuntimeNotFound,
+			wantOCICalled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector := NewDetector(tt.oci, tt.cri, nil, tt.opts...)
+			detector.override = ""
+
+			rt, err := detector.DetectFirst(context.Background())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("DetectFirst() error = %v, want %v", err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("DetectFirst() error = %v", err)
+				}
+				if rt.Name != tt.want {
+					t.Errorf("DetectFirst() = %s, want %s", rt.Name, tt.want)
+				}
+			}
+
+			if called := tt.oci.calls.Load() > 0; called != tt.wantOCICalled {
+				t.Errorf("OCI detector called = %v, want %v", called, tt.wantOCICalled)
+			}
+		})
+	}
+}
+
+func TestDetector_DetectFirst_Override(t *testing.T) {
+	t.Parallel()
+
+	cri := &countingCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}}
+	oci := &countingCRIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}}
+
+	detector := NewDetector(oci, cri, nil, WithOverride(Runc))
+
+	rt, err := detector.DetectFirst(context.Background())
+	if err != nil {
+		t.Fatalf("DetectFirst() error = %v", err)
+	}
+	if rt.Name != Runc {
+		t.Errorf("DetectFirst() = %s, want %s", rt.Name, Runc)
+	}
+	if cri.calls.Load() != 0 {
+		t.Error("CRI detector probed despite OTC_RUNTIME override")
+	}
+
+	detector = NewDetector(oci, cri, nil, WithOverride(Crun), WithEmptyResults())
+	if _, err := detector.DetectFirst(context.Background()); !errors.Is(err, ErrRuntimeNotFound) {
+		t.Errorf("DetectFirst() error = %v, want %v", err, ErrRuntimeNotFound)
+	}
+}
+
+func TestDetector_DetectFirst_Errors(t *testing.T) {
+	t.Parallel()
+
+	detector := NewDetector(nil, nil, nil)
+	detector.override = ""
+	if _, err := detector.DetectFirst(context.Background()); !errors.Is(err, ErrNoDetectors) {
+		t.Errorf("DetectFirst() error = %v, want %v", err, ErrNoDetectors)
+	}
+
+	if err := detector.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := detector.DetectFirst(context.Background()); !errors.Is(err, ErrDetectorClosed) {
+		t.Errorf("DetectFirst() error = %v, want %v", err, ErrDetectorClosed)
+	}
+}
@@ -0,0 +1,23 @@
+package runtime
+
+import "context"
+
+// DetectGrouped runs Detect and also returns the detected runtimes grouped by Type.
+// Each group keeps the priority order of Result.Runtimes; types with no detected
+// runtime are omitted from the map. Errors are those of Detect.
+func (d *Detector) DetectGrouped(ctx context.Context) (map[Type][]Runtime, *Result, error) {
+	result, err := d.Detect(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return groupByType(result.Runtimes), result, nil
+}
+
+// groupByType groups runtimes by Type, preserving their relative order.
+func groupByType(runtimes []Runtime) map[Type][]Runtime {
+	groups := make(map[Type][]Runtime)
+	for _, rt := range runtimes {
+		groups[rt.Type] = append(groups[rt.Type], rt)
+	}
+	return groups
+}
@@ -0,0 +1,51 @@
+package runtime
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDetector_DetectGrouped(t *testing.T) {
+	t.Parallel()
+
+	runc := Runtime{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}
+	crun := Runtime{Name: Crun, Type: TypeOCI, Priority: PriorityOCI}
+	containerd := Runtime{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}
+
+	detector := &Detector{
+		oci: &fakeOCIDetector{runtimes: []Runtime{runc, crun}},
+		cri: &fakeCRIDetector{runtimes: []Runtime{containerd}},
+	}
+
+	groups, result, err := detector.DetectGrouped(context.Background())
+	if err != nil {
+		t.Fatalf("DetectGrouped() error = %v", err)
+	}
+
+	want := map[Type][]Runtime{
+		TypeOCI: {runc, crun},
+		TypeCRI: {containerd},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("DetectGrouped() groups = %v, want %v", groups, want)
+	}
+	if _, ok := groups[TypePodman]; ok {
+		t.Error("DetectGrouped() groups contain empty podman type")
+	}
+	if result.RuntimeCount() != 3 || result.Selected == nil || result.Selected.Name != Containerd {
+		t.Errorf("DetectGrouped() result = %+v, want 3 runtimes with containerd selected", result)
+	}
+}
+
+func TestDetector_DetectGrouped_Error(t *testing.T) {
+	t.Parallel()
+
+	detector := &Detector{}
+	detector.closed.Store(true)
+
+	groups, result, err := detector.DetectGrouped(context.Background())
+	if err != ErrDetectorClosed || groups != nil || result != nil {
+		t.Errorf("DetectGrouped() = %v, %v, %v; want nil, nil, ErrDetectorClosed", groups, result, err)
+	}
+}
@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"strings"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// defaultHandlerName stands in for the unnamed default CRI runtime handler.
+const defaultHandlerName = "default"
+
+// formatRuntimeHandlers formats the runtime handlers from a CRI Status response
+// for Capabilities["handlers"]. Handlers are separated by ";" in the order the
+// runtime reports them; each is "name" or "name=feature,feature" with its
+// supported features (recursive_read_only_mounts, user_namespaces).
+// The unnamed default handler is reported as "default". Returns "" when the
+// runtime reports no handlers (CRI runtimes before Kubernetes 1.30).
+func formatRuntimeHandlers(handlers []*runtimeapi.RuntimeHandler) string {
+	entries := make([]string, 0, len(handlers))
+	for _, h := range handlers {
+		name := h.GetName()
+		if name == "" {
+			name = defaultHandlerName
+		}
+
+		var features []string
+		if h.GetFeatures().GetRecursiveReadOnlyMounts() {
+			features = append(features, "recursive_read_only_mounts")
+		}
+		if h.GetFeatures().GetUserNamespaces() {
+			features = append(features, "user_namespaces")
+		}
+
+		if len(features) > 0 {
+			name += "=" + strings.Join(features, ",")
+		}
+		entries = append(entries, name)
+	}
+	return strings.Join(entries, ";")
+}
+
+// runtimeHandlerNames returns the names of the runtime handlers from a CRI
+// Status response for Runtime.Handlers, in the order the runtime reports them.
+// The unnamed default handler is named "default". Returns nil when the runtime
+// reports no handlers.
+func runtimeHandlerNames(handlers []*runtimeapi.RuntimeHandler) []string {
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(handlers))
+	for _, h := range handlers {
+		name := h.GetName()
+		if name == "" {
+			name = defaultHandlerName
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// formatRuntimeFeatures lists the runtime-wide features from a CRI Status
+// response (CRI v1.31+) for Capabilities["criFeatures"], comma-separated by
+// their CRI field names. ok is false when the runtime predates the field; a
+// runtime reporting it with no features enabled yields "" with ok true.
+func formatRuntimeFeatures(features *runtimeapi.RuntimeFeatures) (list string, ok bool) {
+	if features == nil {
+		return "", false
+	}
+
+	var enabled []string
+	if features.GetSupplementalGroupsPolicy() {
+		enabled = append(enabled, "supplemental_groups_policy")
+	}
+	return strings.Join(enabled, ","), true
+}
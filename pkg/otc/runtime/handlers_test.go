@@ -0,0 +1,182 @@
+package runtime
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+func TestFormatRuntimeHandlers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		handlers []*runtimeapi.RuntimeHandler
+		want     string
+	}{
+		{
+			name: "handlers with features",
+			handlers: []*runtimeapi.RuntimeHandler{
+				{Name: "", Features: &runtimeapi.RuntimeHandlerFeatures{RecursiveReadOnlyMounts: true, UserNamespaces: true}},
+				{Name: "runc", Features: &runtimeapi.RuntimeHandlerFeatures{RecursiveReadOnlyMounts: true, UserNamespaces: true}},
+				{Name: "kata", Features: &runtimeapi.RuntimeHandlerFeatures{}},
+				{Name: "gvisor"},
+			},
+			want: "default=recursive_read_only_mounts,user_namespaces;runc=recursive_read_only_mounts,user_namespaces;kata;gvisor",
+		},
+		{
+			name:     "no handlers reported",
+			handlers: nil,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := formatRuntimeHandlers(tt.handlers); got != tt.want {
+				t.Errorf("formatRuntimeHandlers() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntimeHandlerNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		handlers []*runtimeapi.RuntimeHandler
+		want     []string
+	}{
+		{
+			name: "default and named handlers",
+			handlers: []*runtimeapi.RuntimeHandler{
+				{Name: ""},
+				{Name: "runc", Features: &runtimeapi.RuntimeHandlerFeatures{UserNamespaces: true}},
+				{Name: "kata"},
+			},
+			want: []string{"default", "runc", "kata"},
+		},
+		{
+			name:     "no handlers reported",
+			handlers: nil,
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := runtimeHandlerNames(tt.handlers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("runtimeHandlerNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_Handlers(t *testing.T) {
+	t.Parallel()
+
+	svc := &fakeRuntimeService{
+		version: "2.0.0",
+		handlers: []*runtimeapi.RuntimeHandler{
+			{Name: "runc", Features: &runtimeapi.RuntimeHandlerFeatures{RecursiveReadOnlyMounts: true}},
+			{Name: "crun", Features: &runtimeapi.RuntimeHandlerFeatures{UserNamespaces: true}},
+		},
+	}
+
+	detector := NewContainerdDetector()
+	detector.socketPaths = []string{startFakeCRIServer(t, svc, nil)}
+
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	want := "runc=recursive_read_only_mounts;crun=user_namespaces"
+	if got := runtimes[0].Capabilities["handlers"]; got != want {
+		t.Errorf("handlers = %q, want %q", got, want)
+	}
+
+	if got, want := runtimes[0].Handlers, []string{"runc", "crun"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Handlers = %v, want %v", got, want)
+	}
+}
+
+func TestCRIODetector_Handlers(t *testing.T) {
+	t.Parallel()
+
+	svc := &fakeRuntimeService{
+		version:  "1.30.4",
+		name:     "cri-o",
+		handlers: []*runtimeapi.RuntimeHandler{{Name: "runc"}, {Name: "crun"}, {Name: "kata"}},
+	}
+
+	detector := NewCRIODetector()
+	detector.socketPaths = []string{startFakeCRIServer(t, svc, nil)}
+
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	if got, want := runtimes[0].Handlers, []string{"runc", "crun", "kata"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Handlers = %v, want %v", got, want)
+	}
+}
+
+func TestContainerdDetector_CRIFeatures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		features *runtimeapi.RuntimeFeatures
+		want     string
+		wantSet  bool
+	}{
+		{
+			name:     "supplemental groups policy",
+			features: &runtimeapi.RuntimeFeatures{SupplementalGroupsPolicy: true},
+			want:     "supplemental_groups_policy",
+			wantSet:  true,
+		},
+		{
+			name:     "reported without features",
+			features: &runtimeapi.RuntimeFeatures{},
+			want:     "",
+			wantSet:  true,
+		},
+		{
+			name:     "runtime predates features",
+			features: nil,
+			wantSet:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector := NewContainerdDetector()
+			detector.socketPaths = []string{startFakeCRIServer(t, &fakeRuntimeService{version: "2.0.0", features: tt.features}, nil)}
+
+			runtimes, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			got, ok := runtimes[0].Capabilities["criFeatures"]
+			if ok != tt.wantSet || got != tt.want {
+				t.Errorf("criFeatures = %q (set %v), want %q (set %v)", got, ok, tt.want, tt.wantSet)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// HealthCheck probes whether the runtime is able to serve requests, going beyond
+// the version query used during detection.
+// For CRI runtimes it calls the Status RPC and requires the RuntimeReady and
+// NetworkReady conditions to be true. For OCI runtimes it runs "<binary> features",
+// falling back to "<binary> --help" for runtimes without the features subcommand.
+// SSH endpoints need a dialer, so use Detector.HealthCheck for those.
+// Returns nil when healthy, or an error naming the failed condition or probe.
+func (r Runtime) HealthCheck(ctx context.Context) error {
+	return r.healthCheck(ctx, &ContainerdDetector{}, execOutput)
+}
+
+// HealthCheck probes r like Runtime.HealthCheck, but through the detectors d
+// detected it with: CRI endpoints are dialed with the built-in containerd
+// detector's settings, including its SSH dialer, and OCI binaries are run with
+// the built-in OCI detector's command runner. Defaults are used for detectors
+// d doesn't have.
+func (d *Detector) HealthCheck(ctx context.Context, r Runtime) error {
+	cri := d.containerdDetector()
+	if cri == nil {
+		cri = &ContainerdDetector{}
+	}
+	run := execOutput
+	if oci, ok := d.oci.(*ociDetector); ok {
+		run = oci.runner()
+	}
+	return r.healthCheck(ctx, cri, run)
+}
+
+// healthCheck runs the probe for r's type, dialing CRI endpoints with cri and
+// running OCI binaries with run.
+func (r Runtime) healthCheck(ctx context.Context, cri *ContainerdDetector, run commandRunner) error {
+	switch r.Type {
+	case TypeCRI:
+		return r.criHealthCheck(ctx, cri)
+	case TypeOCI:
+		return r.ociHealthCheck(ctx, run)
+	default:
+		return fmt.Errorf("health check not supported for %s runtime %s", r.Type, r.Name)
+	}
+}
+
+// criHealthCheck calls CRI Status on the runtime's endpoint and checks its conditions.
+// The endpoint is dialed with cri, so SSH endpoints fail unless it has a dialer.
+func (r Runtime) criHealthCheck(ctx context.Context, cri *ContainerdDetector) error {
+	ep, err := parseEndpoint(r.Path)
+	if err != nil {
+		return fmt.Errorf("runtime %s unhealthy: %w", r.Name, err)
+	}
+
+	conn, err := cri.dialEndpoint(ep)
+	if err != nil {
+		return fmt.Errorf("runtime %s unhealthy: %w", r.Name, err)
+	}
+	defer closeConn(conn)
+
+	client := runtimeapi.NewRuntimeServiceClient(conn)
+	resp, err := client.Status(ctx, &runtimeapi.StatusRequest{})
+	if err != nil {
+		return fmt.Errorf("runtime %s unhealthy: CRI Status call failed: %w", r.Name, err)
+	}
+	return checkRuntimeConditions(r.Name, resp.GetStatus().GetConditions())
+}
+
+// checkRuntimeConditions requires the RuntimeReady and NetworkReady conditions to be
+// reported and true. All failing conditions are joined into the returned error.
+func checkRuntimeConditions(name string, conditions []*runtimeapi.RuntimeCondition) error {
+	byType := make(map[string]*runtimeapi.RuntimeCondition, len(conditions))
+	for _, c := range conditions {
+		byType[c.GetType()] = c
+	}
+
+	var errs []error
+	for _, required := range []string{runtimeapi.RuntimeReady, runtimeapi.NetworkReady} {
+		c, ok := byType[required]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("runtime %s unhealthy: condition %s not reported", name, required))
+		case !c.GetStatus():
+			errs = append(errs, fmt.Errorf("runtime %s unhealthy: condition %s is false (reason: %q, message: %q)",
+				name, required, c.GetReason(), c.GetMessage()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ociHealthCheck runs the OCI binary as a liveness probe, using run to execute it.
+func (r Runtime) ociHealthCheck(ctx context.Context, run commandRunner) error {
+	_, featuresErr := run(ctx, r.Path, "features")
+	if featuresErr == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("runtime %s unhealthy: %s features: %w", r.Name, r.Path, ctx.Err())
+	}
+
+	if _, err := run(ctx, r.Path, "--help"); err != nil {
+		return fmt.Errorf("runtime %s unhealthy: %s features failed (%v) and %s --help failed: %w",
+			r.Name, r.Path, featuresErr, r.Path, err)
+	}
+	return nil
+}
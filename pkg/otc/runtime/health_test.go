@@ -0,0 +1,240 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// conditionRuntimeService is a CRI runtime service that reports fixed conditions.
+type conditionRuntimeService struct {
+	runtimeapi.UnimplementedRuntimeServiceServer
+
+	conditions []*runtimeapi.RuntimeCondition
+}
+
+// Status reports the configured conditions.
+func (s conditionRuntimeService) Status(context.Context, *runtimeapi.StatusRequest) (*runtimeapi.StatusResponse, error) {
+	return &runtimeapi.StatusResponse{
+		Status: &runtimeapi.RuntimeStatus{Conditions: s.conditions},
+	}, nil
+}
+
+func TestRuntime_HealthCheck_CRI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		svc     runtimeapi.RuntimeServiceServer
+		wantErr string
+	}{
+		{
+			name: "ready",
+			svc:  &fakeRuntimeService{version: "1.7.0"},
+		},
+		{
+			name: "network not ready",
+			svc: conditionRuntimeService{conditions: []*runtimeapi.RuntimeCondition{
+				{Type: runtimeapi.RuntimeReady, Status: true},
+				{Type: runtimeapi.NetworkReady, Status: false, Reason: "NetworkPluginNotReady", Message: "cni config uninitialized"},
+			}},
+			wantErr: `condition NetworkReady is false (reason: "NetworkPluginNotReady", message: "cni config uninitialized")`,
+		},
+		{
+			name: "runtime condition missing",
+			svc: conditionRuntimeService{conditions: []*runtimeapi.RuntimeCondition{
+				{Type: runtimeapi.NetworkReady, Status: true},
+			}},
+			wantErr: "condition RuntimeReady not reported",
+		},
+		{
+			name:    "status unimplemented",
+			svc:     hangingRuntimeService{},
+			wantErr: "CRI Status call failed",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			socket := startFakeCRIServer(t, tt.svc, nil)
+			rt := Runtime{Name: Containerd, Type: TypeCRI, Path: socket}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			err := rt.HealthCheck(ctx)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("HealthCheck() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("HealthCheck() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDetector_HealthCheck_SSHEndpoint(t *testing.T) {
+	t.Parallel()
+
+	socket := startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.0"}, nil)
+	ssh := &fakeSSHDialer{local: socket, calls: make(chan endpoint, 8)}
+	rt := Runtime{Name: Containerd, Type: TypeCRI, Path: "ssh://core@node1/run/containerd/containerd.sock"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The Runtime alone has no dialer for the SSH endpoint
+	if err := rt.HealthCheck(ctx); err == nil {
+		t.Error("Runtime.HealthCheck() error = nil, want missing SSH dialer")
+	}
+
+	// The Detector dials through its containerd detector's SSH dialer
+	detector := NewDetector(nil, multiCRIDetector{NewContainerdDetector(WithSSHDialer(ssh)), NewCRIODetector()}, nil)
+	if err := detector.HealthCheck(ctx, rt); err != nil {
+		t.Fatalf("Detector.HealthCheck() error = %v, want nil", err)
+	}
+	want := endpoint{scheme: schemeSSH, user: "core", addr: "node1:22", path: "/run/containerd/containerd.sock"}
+	if got := <-ssh.calls; got != want {
+		t.Errorf("DialUnix() target = %+v, want %+v", got, want)
+	}
+}
+
+func TestCheckRuntimeConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		conditions []*runtimeapi.RuntimeCondition
+		want       []string
+	}{
+		{
+			name: "all ready",
+			conditions: []*runtimeapi.RuntimeCondition{
+				{Type: runtimeapi.RuntimeReady, Status: true},
+				{Type: runtimeapi.NetworkReady, Status: true},
+			},
+		},
+		{
+			name: "both failing",
+			conditions: []*runtimeapi.RuntimeCondition{
+				{Type: runtimeapi.RuntimeReady, Status: false, Reason: "Starting"},
+			},
+			want: []string{"condition RuntimeReady is false", "condition NetworkReady not reported"},
+		},
+		{
+			name: "no conditions",
+			want: []string{"condition RuntimeReady not reported", "condition NetworkReady not reported"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkRuntimeConditions(CRIO, tt.conditions)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("checkRuntimeConditions() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("checkRuntimeConditions() error = nil, want error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("checkRuntimeConditions() error = %v, want containing %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRuntime_HealthCheck_OCI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{
+			name:   "features succeeds",
+			script: `[ "$1" = features ] && echo '{}'`,
+		},
+		{
+			name:   "help fallback",
+			script: `[ "$1" = --help ]`,
+		},
+		{
+			name:    "both fail",
+			script:  "exit 1",
+			wantErr: "features failed",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := writeFakeBinary(t, t.TempDir(), Runc, tt.script)
+			rt := Runtime{Name: Runc, Type: TypeOCI, Path: path}
+
+			err := rt.HealthCheck(context.Background())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("HealthCheck() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("HealthCheck() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuntime_ociHealthCheck_Canceled(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	run := func(ctx context.Context, _ string, args ...string) ([]byte, error) {
+		calls = append(calls, strings.Join(args, " "))
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	rt := Runtime{Name: Runc, Type: TypeOCI, Path: "/opt/fake/runc"}
+	if err := rt.ociHealthCheck(ctx, run); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ociHealthCheck() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	// The --help fallback is pointless once the deadline has passed
+	if len(calls) != 1 || calls[0] != "features" {
+		t.Errorf("runner calls = %q, want only features", calls)
+	}
+}
+
+func TestRuntime_HealthCheck_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	rt := Runtime{Name: Podman, Type: TypePodman, Path: "/usr/bin/podman"}
+	err := rt.HealthCheck(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("HealthCheck() error = %v, want unsupported error", err)
+	}
+}
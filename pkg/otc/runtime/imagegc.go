@@ -0,0 +1,83 @@
+package runtime
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Kubelet image garbage collection defaults, in percent of disk usage
+const (
+	defaultImageGCHigh = 85
+	defaultImageGCLow  = 80
+)
+
+// checkImageGC records the image GC thresholds that apply to CRI runtimes in
+// Capabilities["imageGC"] (e.g. "high=85,low=80") and warns on extreme values.
+// Nothing is checked unless a CRI runtime was detected.
+//
+// The thresholds are read from the kubelet config (imageGCHighThresholdPercent,
+// imageGCLowThresholdPercent), not from containerd's config or CRI status:
+// containerd has no image GC thresholds of its own and leaves image garbage
+// collection to the kubelet. Unset values fall back to kubelet defaults. When
+// the kubelet config isn't readable the thresholds are unknown, so a
+// low-severity warning naming the path is returned instead.
+func checkImageGC(runtimes []Runtime, k8s kubernetesProbe) []error {
+	if !slices.ContainsFunc(runtimes, func(rt Runtime) bool { return rt.Type == TypeCRI }) {
+		return nil
+	}
+
+	config, ok := k8s.kubeletConfig()
+	if !ok {
+		return []error{newWarning(SeverityLow,
+			"image GC thresholds unknown: kubelet config %s not readable", k8s.kubeletConfigPath())}
+	}
+
+	high, highErr := percentSetting(config, "imageGCHighThresholdPercent", defaultImageGCHigh)
+	low, lowErr := percentSetting(config, "imageGCLowThresholdPercent", defaultImageGCLow)
+
+	var warnings []error
+	for _, err := range []error{highErr, lowErr} {
+		if err != nil {
+			warnings = append(warnings, err)
+		}
+	}
+
+	switch {
+	case low >= high:
+		warnings = append(warnings, newWarning(SeverityHigh,
+			"image GC low threshold %d%% is not below high threshold %d%%", low, high))
+	case high <= 50:
+		warnings = append(warnings, newWarning(SeverityMedium,
+			"image GC high threshold %d%% is aggressive; images will churn", high))
+	case high >= 95:
+		warnings = append(warnings, newWarning(SeverityHigh,
+			"image GC high threshold %d%% risks running out of disk before collection", high))
+	}
+
+	for i := range runtimes {
+		if runtimes[i].Type != TypeCRI {
+			continue
+		}
+		if runtimes[i].Capabilities == nil {
+			runtimes[i].Capabilities = make(map[string]string)
+		}
+		runtimes[i].Capabilities["imageGC"] = fmt.Sprintf("high=%d,low=%d", high, low)
+	}
+
+	return warnings
+}
+
+// percentSetting parses an integer percentage setting, returning def when unset.
+// Unparseable values also yield def, with a warning.
+func percentSetting(config map[string]string, key string, def int) (int, error) {
+	raw, ok := config[key]
+	if !ok || raw == "" {
+		return def, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 || value > 100 {
+		return def, newWarning(SeverityMedium, "invalid kubelet %s %q, assuming %d", key, raw, def)
+	}
+	return value, nil
+}
@@ -0,0 +1,130 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckImageGC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		kubeletConfig string
+		wantCap       string
+		wantWarn      string
+	}{
+		{
+			name:          "defaults",
+			kubeletConfig: "kind: KubeletConfiguration\n",
+			wantCap:       "high=85,low=80",
+		},
+		{
+			name:          "custom thresholds",
+			kubeletConfig: "imageGCHighThresholdPercent: 90\nimageGCLowThresholdPercent: 70\n",
+			wantCap:       "high=90,low=70",
+		},
+		{
+			name:          "aggressive",
+			kubeletConfig: "imageGCHighThresholdPercent: 40\nimageGCLowThresholdPercent: 20\n",
+			wantCap:       "high=40,low=20",
+			wantWarn:      "aggressive",
+		},
+		{
+			name:          "too late",
+			kubeletConfig: "imageGCHighThresholdPercent: 99\nimageGCLowThresholdPercent: 90\n",
+			wantCap:       "high=99,low=90",
+			wantWarn:      "risks running out of disk",
+		},
+		{
+			name:          "inverted",
+			kubeletConfig: "imageGCHighThresholdPercent: 70\nimageGCLowThresholdPercent: 80\n",
+			wantCap:       "high=70,low=80",
+			wantWarn:      "not below high threshold",
+		},
+		{
+			name:          "invalid value",
+			kubeletConfig: "imageGCHighThresholdPercent: lots\n",
+			wantCap:       "high=85,low=80",
+			wantWarn:      "invalid kubelet imageGCHighThresholdPercent",
+		},
+		{
+			name:          "no kubelet config",
+			kubeletConfig: "",
+			wantCap:       "",
+			wantWarn:      filepath.Join("var", "lib", "kubelet", "config.yaml") + " not readable",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			if tt.kubeletConfig != "" {
+				dir := filepath.Join(root, "var", "lib", "kubelet")
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					t.Fatalf("failed to create kubelet dir: %v", err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.kubeletConfig), 0644); err != nil {
+					t.Fatalf("failed to write kubelet config: %v", err)
+				}
+			}
+
+			runtimes := []Runtime{
+				{Name: Containerd, Type: TypeCRI},
+				{Name: Runc, Type: TypeOCI},
+			}
+			warnings := checkImageGC(runtimes, kubernetesProbe{root: root})
+
+			if got := runtimes[0].Capabilities["imageGC"]; got != tt.wantCap {
+				t.Errorf("imageGC = %q, want %q", got, tt.wantCap)
+			}
+			if runtimes[1].Capabilities != nil {
+				t.Errorf("OCI runtime got capabilities %v", runtimes[1].Capabilities)
+			}
+
+			if tt.wantWarn == "" {
+				if len(warnings) != 0 {
+					t.Errorf("expected no warnings, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !contains(warnings[0].Error(), tt.wantWarn) {
+				t.Errorf("warnings = %v, want one containing %q", warnings, tt.wantWarn)
+			}
+			if tt.kubeletConfig == "" && WarningSeverity(warnings[0]) != SeverityLow {
+				t.Errorf("severity = %v, want %v", WarningSeverity(warnings[0]), SeverityLow)
+			}
+		})
+	}
+}
+
+func TestCheckImageGC_NoCRIRuntime(t *testing.T) {
+	t.Parallel()
+
+	// Without a CRI runtime the thresholds don't apply: neither a missing
+	// kubelet config nor extreme thresholds are worth a warning
+	root := t.TempDir()
+	runtimes := []Runtime{{Name: Docker, Type: TypeDocker}, {Name: Runc, Type: TypeOCI}}
+	if warnings := checkImageGC(runtimes, kubernetesProbe{root: root}); warnings != nil {
+		t.Errorf("missing config: expected no warnings, got %v", warnings)
+	}
+
+	dir := filepath.Join(root, "var", "lib", "kubelet")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create kubelet dir: %v", err)
+	}
+	config := "imageGCHighThresholdPercent: 99\nimageGCLowThresholdPercent: 90\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write kubelet config: %v", err)
+	}
+	if warnings := checkImageGC(runtimes, kubernetesProbe{root: root}); warnings != nil {
+		t.Errorf("extreme thresholds: expected no warnings, got %v", warnings)
+	}
+	if runtimes[0].Capabilities != nil {
+		t.Errorf("Docker runtime got capabilities %v", runtimes[0].Capabilities)
+	}
+}
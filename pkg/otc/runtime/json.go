@@ -0,0 +1,56 @@
+package runtime
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// resultJSON is the serialized form of Result.
+type resultJSON struct {
+	Runtimes       []Runtime `json:"runtimes"`
+	Selected       *Runtime  `json:"selected"`
+	Mode           Mode      `json:"mode"`
+	KubernetesNode bool      `json:"kubernetesNode"`
+	DetectedAt     time.Time `json:"detectedAt,omitzero"`
+	Duration       string    `json:"duration,omitempty"`
+	Warnings       []string  `json:"warnings"`
+	HasWarnings    bool      `json:"hasWarnings"`
+}
+
+// MarshalJSON renders the Result as:
+//
+//	{
+//	  "runtimes": [{"name": "containerd", "type": "cri", "version": "1.7.13", ...}],
+//	  "selected": {"name": "containerd", ...},
+//	  "mode": "auto",
+//	  "kubernetesNode": false,
+//	  "detectedAt": "2025-01-02T15:04:05.123456789Z",
+//	  "duration": "42.5ms",
+//	  "warnings": ["runc not found in PATH"],
+//	  "hasWarnings": true
+//	}
+//
+// Warnings are rendered as their error messages. runtimes and warnings are
+// always arrays, and selected is null when nothing was detected. detectedAt and
+// duration are omitted for results not returned by a Detector.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	out := resultJSON{
+		Runtimes:       r.Runtimes,
+		Selected:       r.Selected,
+		Mode:           r.Mode,
+		KubernetesNode: r.KubernetesNode,
+		DetectedAt:     r.DetectedAt,
+		Warnings:       make([]string, 0, len(r.Warnings)),
+		HasWarnings:    r.HasWarnings(),
+	}
+	if r.Duration > 0 {
+		out.Duration = r.Duration.String()
+	}
+	if out.Runtimes == nil {
+		out.Runtimes = []Runtime{}
+	}
+	for _, w := range r.Warnings {
+		out.Warnings = append(out.Warnings, w.Error())
+	}
+	return json.Marshal(out)
+}
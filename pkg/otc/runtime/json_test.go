@@ -0,0 +1,64 @@
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResult_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		result *Result
+		want   string
+	}{
+		{
+			name: "runtimes with warnings",
+			result: NewResult([]Runtime{
+				{Name: Runc, Type: TypeOCI, Version: "1.1.12", Path: "/usr/bin/runc", Priority: PriorityOCI},
+				{
+					Name: Containerd, Type: TypeCRI, Version: "1.7.13", Path: "/run/containerd/containerd.sock",
+					Priority: PriorityCRI, Capabilities: map[string]string{"criCheckpoint": "true"},
+				},
+			}, []error{errors.New("podman not found")}),
+			want: `{"runtimes":[` +
+				`{"name":"containerd","type":"cri","version":"1.7.13","path":"/run/containerd/containerd.sock","priority":100,"rootless":false,"capabilities":{"criCheckpoint":"true"}},` +
+				`{"name":"runc","type":"oci","version":"1.1.12","path":"/usr/bin/runc","priority":70,"rootless":false}],` +
+				`"selected":{"name":"containerd","type":"cri","version":"1.7.13","path":"/run/containerd/containerd.sock","priority":100,"rootless":false,"capabilities":{"criCheckpoint":"true"}},` +
+				`"mode":"auto","kubernetesNode":false,"warnings":["podman not found"],"hasWarnings":true}`,
+		},
+		{
+			name: "detection time",
+			result: &Result{
+				Mode:       ModeAuto,
+				DetectedAt: time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC),
+				Duration:   42500 * time.Microsecond,
+			},
+			want: `{"runtimes":[],"selected":null,"mode":"auto","kubernetesNode":false,` +
+				`"detectedAt":"2025-01-02T15:04:05Z","duration":"42.5ms","warnings":[],"hasWarnings":false}`,
+		},
+		{
+			name:   "empty result",
+			result: NewResult(nil, nil),
+			want:   `{"runtimes":[],"selected":null,"mode":"auto","kubernetesNode":false,"warnings":[],"hasWarnings":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(tt.result)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// kubernetesProbe detects whether the host is a Kubernetes node.
+type kubernetesProbe struct {
+	root string // Filesystem root, "" for the real host
+}
+
+// isNode combines several kubelet indicators for robustness:
+// a running kubelet process is conclusive on its own, while the kubelet
+// state directory and kubeconfig must both be present, since either alone
+// may be left behind by an uninstalled node.
+func (p kubernetesProbe) isNode() bool {
+	if len(findDaemonPIDs(p.path("/proc"), "kubelet")) > 0 {
+		return true
+	}
+
+	return dirExists(p.path("/var/lib/kubelet")) && fileExists(p.path("/etc/kubernetes/kubelet.conf"))
+}
+
+// kubeletConfig reads the top-level scalar settings of the kubelet config file
+// (/var/lib/kubelet/config.yaml) as unquoted strings.
+// Returns false if the file can't be read.
+func (p kubernetesProbe) kubeletConfig() (map[string]string, bool) {
+	data, err := os.ReadFile(p.kubeletConfigPath())
+	if err != nil {
+		return nil, false
+	}
+
+	config := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "#") {
+			continue // Nested or commented setting
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		config[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return config, true
+}
+
+// kubeletConfigPath returns the path of the kubelet config file under the probe root.
+func (p kubernetesProbe) kubeletConfigPath() string {
+	return p.path("/var/lib/kubelet/config.yaml")
+}
+
+// path resolves an absolute host path under the probe's root.
+func (p kubernetesProbe) path(name string) string {
+	return filepath.Join(p.root, name)
+}
+
+// dirExists reports whether path exists and is a directory.
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// fileExists reports whether path exists and is a regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
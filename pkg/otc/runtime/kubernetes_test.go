@@ -0,0 +1,96 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKubernetesProbe_IsNode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		dirs  []string
+		files map[string]string
+		want  bool
+	}{
+		{
+			name: "standalone host",
+			want: false,
+		},
+		{
+			name:  "kubelet running",
+			dirs:  []string{"/proc/1234"},
+			files: map[string]string{"/proc/1234/comm": "kubelet\n"},
+			want:  true,
+		},
+		{
+			name:  "kubelet state and config",
+			dirs:  []string{"/var/lib/kubelet", "/etc/kubernetes"},
+			files: map[string]string{"/etc/kubernetes/kubelet.conf": "apiVersion: v1\n"},
+			want:  true,
+		},
+		{
+			name: "leftover state directory only",
+			dirs: []string{"/var/lib/kubelet"},
+			want: false,
+		},
+		{
+			name:  "other process running",
+			dirs:  []string{"/proc/1"},
+			files: map[string]string{"/proc/1/comm": "systemd\n"},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			for _, dir := range tt.dirs {
+				if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+					t.Fatalf("failed to create %s: %v", dir, err)
+				}
+			}
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+
+			probe := kubernetesProbe{root: root}
+			if got := probe.isNode(); got != tt.want {
+				t.Errorf("isNode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetector_Detect_KubernetesNode(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "proc", "42"), 0755); err != nil {
+		t.Fatalf("failed to create proc dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "proc", "42", "comm"), []byte("kubelet\n"), 0644); err != nil {
+		t.Fatalf("failed to write comm: %v", err)
+	}
+
+	detector := &Detector{
+		cri:        &fakeCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}},
+		kubernetes: kubernetesProbe{root: root},
+	}
+
+	result, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if !result.KubernetesNode {
+		t.Error("expected KubernetesNode to be true")
+	}
+}
@@ -0,0 +1,30 @@
+package runtime
+
+import "log/slog"
+
+// discardLogger is the no-op logger used when WithLogger is not set.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// Reasons logged when a candidate socket is passed over
+const (
+	skipRecentlyMissing = "recently missing"
+	skipNotExist        = "does not exist"
+	skipBrokenSymlink   = "broken symlink"
+	skipStatFailed      = "stat failed"
+	skipNotSocket       = "not a socket"
+	skipPermission      = "permission denied"
+)
+
+// loggerOrDiscard returns l, or discardLogger if l is nil, so detectors built
+// without NewDetector or WithLogger can log unconditionally.
+func loggerOrDiscard(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return discardLogger
+	}
+	return l
+}
+
+// logSocketSkipped logs at debug level why a candidate socket was passed over.
+func logSocketSkipped(l *slog.Logger, runtime, path, reason string) {
+	l.Debug("socket skipped", "runtime", runtime, "path", path, "reason", reason)
+}
@@ -0,0 +1,113 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes from a slog handler.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// newDebugLogger returns a logger writing debug-level text records to out.
+func newDebugLogger(out *syncBuffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func TestLoggerOrDiscard(t *testing.T) {
+	t.Parallel()
+
+	if got := loggerOrDiscard(nil); got != discardLogger {
+		t.Errorf("loggerOrDiscard(nil) = %v, want discardLogger", got)
+	}
+
+	logger := slog.Default()
+	if got := loggerOrDiscard(logger); got != logger {
+		t.Errorf("loggerOrDiscard(logger) = %v, want logger", got)
+	}
+}
+
+func TestDetector_WithLogger(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.sock")
+	regular := filepath.Join(dir, "regular.sock")
+	if err := os.WriteFile(regular, nil, 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	socket := startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.20"}, nil)
+
+	cri := NewContainerdDetector()
+	cri.socketPaths = []string{missing, regular, socket}
+
+	var out syncBuffer
+	detector := NewDetector(&fakeOCIDetector{}, cri, nil, WithLogger(newDebugLogger(&out)))
+	detector.override = ""
+
+	if _, err := detector.Detect(context.Background()); err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	logs := out.String()
+	for _, want := range []string{
+		"running detector\" type=oci",
+		"running detector\" type=cri",
+		"path=" + missing + " reason=\"does not exist\"",
+		"path=" + regular + " reason=\"not a socket\"",
+		"socket found\" runtime=containerd path=" + socket,
+		"CRI version queried\" runtime=containerd",
+		"detector finished\" type=cri found=[containerd]",
+	} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("logs missing %q:\n%s", want, logs)
+		}
+	}
+}
+
+func TestOCIDetector_Logger(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	writeFakeBinary(t, binDir, Runc, `echo "runc version 1.1.12"`)
+
+	var out syncBuffer
+	detector := NewOCIDetector().(*ociDetector)
+	detector.setLogger(newDebugLogger(&out))
+
+	if _, err := detector.Detect(context.Background()); err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	logs := out.String()
+	for _, want := range []string{
+		"version parsed\" runtime=runc",
+		"version=1.1.12",
+		"binary not found in PATH\" runtime=crun",
+	} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("logs missing %q:\n%s", want, logs)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package runtime
+
+// verboseLogLevels are daemon log levels too chatty for production use.
+var verboseLogLevels = map[string]bool{
+	"debug": true,
+	"trace": true,
+}
+
+// daemonLogSettings reads the log level and format from the [debug] table of a
+// containerd-style TOML config, reporting them as "logLevel" and "logFormat".
+// The CRI status config does not carry daemon-wide settings, so the file is read
+// directly. Returns nil when the file is unreadable or sets neither key.
+func daemonLogSettings(configPath string) map[string]string {
+	entries, err := readTOMLEntries(configPath)
+	if err != nil {
+		return nil
+	}
+
+	var caps map[string]string
+	for _, e := range entries {
+		var capability string
+		switch e.name() {
+		case "debug.level":
+			capability = "logLevel"
+		case "debug.format":
+			capability = "logFormat"
+		default:
+			continue
+		}
+		if e.key != "" && e.value != "" {
+			if caps == nil {
+				caps = make(map[string]string)
+			}
+			caps[capability] = e.value
+		}
+	}
+	return caps
+}
+
+// checkLogLevel warns about runtimes left running with debug or trace logging,
+// which slows container operations and fills disks on busy nodes.
+func checkLogLevel(runtimes []Runtime) []error {
+	var warnings []error
+	for _, rt := range runtimes {
+		if level := rt.Capabilities["logLevel"]; verboseLogLevels[level] {
+			warnings = append(warnings, newWarning(SeverityMedium,
+				"%s runs with %s logging; use info or higher in production", rt.Name, level))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,131 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDaemonLogSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config string
+		want   map[string]string
+	}{
+		{
+			name: "debug level and json format",
+			config: `version = 2
+root = "/var/lib/containerd"
+
+[debug]
+  address = "/run/containerd/debug.sock"
+  level = "debug"
+  format = "json"
+
+[plugins."io.containerd.grpc.v1.cri"]
+  level = "ignored"
+`,
+			want: map[string]string{"logLevel": "debug", "logFormat": "json"},
+		},
+		{
+			name: "trailing comments",
+			config: `[debug] # daemon logging
+  level = "debug" # verbose
+  format = "json"#compact
+`,
+			want: map[string]string{"logLevel": "debug", "logFormat": "json"},
+		},
+		{
+			name:   "dotted key",
+			config: "debug.level = \"trace\"\n",
+			want:   map[string]string{"logLevel": "trace"},
+		},
+		{
+			name:   "level only",
+			config: "[debug]\nlevel = 'warn'\n",
+			want:   map[string]string{"logLevel": "warn"},
+		},
+		{
+			name:   "no debug table",
+			config: "version = 2\n[grpc]\n  address = \"/run/containerd/containerd.sock\"\n",
+			want:   nil,
+		},
+		{
+			name:   "empty level",
+			config: "[debug]\n  level = \"\"\n",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(configPath, []byte(tt.config), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			if got := daemonLogSettings(configPath); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("daemonLogSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLogLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		level        string
+		wantWarnings int
+	}{
+		{name: "debug", level: "debug", wantWarnings: 1},
+		{name: "trace", level: "trace", wantWarnings: 1},
+		{name: "info", level: "info", wantWarnings: 0},
+		{name: "unknown", level: "", wantWarnings: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := Runtime{Name: Containerd, Type: TypeCRI}
+			if tt.level != "" {
+				rt.Capabilities = map[string]string{"logLevel": tt.level}
+			}
+
+			if got := checkLogLevel([]Runtime{rt}); len(got) != tt.wantWarnings {
+				t.Errorf("checkLogLevel() = %v, want %d warnings", got, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_LogLevel(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, []byte("[debug]\n  level = \"debug\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	detector := NewContainerdDetector()
+	detector.socketPaths = []string{startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.0"}, nil)}
+	detector.configPath = configPath
+
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if got := runtimes[0].Capabilities["logLevel"]; got != "debug" {
+		t.Errorf("logLevel = %q, want %q", got, "debug")
+	}
+}
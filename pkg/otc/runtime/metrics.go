@@ -0,0 +1,38 @@
+package runtime
+
+import "time"
+
+// MetricsHook receives detection metrics, so callers can export them to the
+// metrics backend of their choice (Prometheus, OpenTelemetry, expvar, ...)
+// without this package depending on one. Set it with WithMetrics.
+//
+// Methods are called synchronously from detection and must be safe for
+// concurrent use, since a Detector may be used by several goroutines.
+type MetricsHook interface {
+	// ObserveDetectLatency records how long one run of a detector took.
+	// detector is the detector's Type, e.g. "cri".
+	ObserveDetectLatency(detector string, d time.Duration)
+
+	// IncDetectSuccess counts a runtime found by a detector, by runtime name.
+	IncDetectSuccess(name string)
+
+	// IncDetectFailure counts a detector run that failed, including one that
+	// found no runtime. Runs returning only a warning are not failures.
+	IncDetectFailure(detector string)
+}
+
+// observeRun reports one detector run to the metrics hook, if any.
+func (d *Detector) observeRun(typ Type, elapsed time.Duration, found []Runtime, err error) {
+	if d.metrics == nil {
+		return
+	}
+
+	d.metrics.ObserveDetectLatency(string(typ), elapsed)
+	if err != nil && !isWarning(err) {
+		d.metrics.IncDetectFailure(string(typ))
+		return
+	}
+	for _, rt := range found {
+		d.metrics.IncDetectSuccess(rt.Name)
+	}
+}
@@ -0,0 +1,108 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics is a MetricsHook that records every call.
+type recordingMetrics struct {
+	mu        sync.Mutex
+	latencies []string
+	successes []string
+	failures  []string
+}
+
+func (m *recordingMetrics) ObserveDetectLatency(detector string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if d >= 0 {
+		m.latencies = append(m.latencies, detector)
+	}
+}
+
+func (m *recordingMetrics) IncDetectSuccess(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.successes = append(m.successes, name)
+}
+
+func (m *recordingMetrics) IncDetectFailure(detector string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures = append(m.failures, detector)
+}
+
+func TestDetector_WithMetrics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		override      string
+		oci           *fakeOCIDetector
+		cri           *fakeCRIDetector
+		wantLatencies []string
+		wantSuccesses []string
+		wantFailures  []string
+	}{
+		{
+			name: "auto detection",
+			oci: &fakeOCIDetector{runtimes: []Runtime{
+				{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+				{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+			}},
+			cri:           &fakeCRIDetector{err: errors.New("containerd socket not found")},
+			wantLatencies: []string{"cri", "oci"},
+			wantSuccesses: []string{Crun, Runc},
+			wantFailures:  []string{"cri"},
+		},
+		{
+			name:          "warning is not a failure",
+			oci:           &fakeOCIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}, err: newWarning(SeverityLow, "kept runtimes with unparseable versions: runc")},
+			cri:           &fakeCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}},
+			wantLatencies: []string{"cri", "oci"},
+			wantSuccesses: []string{Containerd, Runc},
+		},
+		{
+			name:          "override",
+			override:      Containerd,
+			oci:           &fakeOCIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}},
+			cri:           &fakeCRIDetector{runtimes: []Runtime{{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}}},
+			wantLatencies: []string{"cri"},
+			wantSuccesses: []string{Containerd},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			metrics := &recordingMetrics{}
+			detector := NewDetector(tt.oci, tt.cri, nil, WithMetrics(metrics), WithOverride(tt.override))
+
+			if _, err := detector.Detect(context.Background()); err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			for _, check := range []struct {
+				what      string
+				got, want []string
+			}{
+				{"latencies", metrics.latencies, tt.wantLatencies},
+				{"successes", metrics.successes, tt.wantSuccesses},
+				{"failures", metrics.failures, tt.wantFailures},
+			} {
+				sort.Strings(check.got)
+				if !reflect.DeepEqual(check.got, check.want) {
+					t.Errorf("%s = %v, want %v", check.what, check.got, check.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+)
+
+// multiCRIDetector fills the Detector's single CRI slot with several CRI
+// detectors, e.g. containerd and CRI-O, running each in order.
+type multiCRIDetector []CRIDetector
+
+// Detect runs every CRI detector and returns all runtimes found. Since a host
+// usually runs only one CRI runtime, the others not being found is expected
+// and dropped once something is found; other failures become warnings.
+// Returns error joining every detector's error if none finds a runtime.
+func (m multiCRIDetector) Detect(ctx context.Context) ([]Runtime, error) {
+	var runtimes []Runtime
+	var warnings, errs []error
+	for _, cri := range m {
+		found, err := cri.Detect(ctx)
+		switch {
+		case err == nil:
+		case isWarning(err):
+			warnings = append(warnings, err)
+		default:
+			errs = append(errs, err)
+			continue
+		}
+		runtimes = append(runtimes, found...)
+	}
+
+	if len(runtimes) == 0 {
+		return nil, errors.Join(append(errs, warnings...)...)
+	}
+	for _, err := range errs {
+		if !errors.Is(err, ErrRuntimeNotFound) {
+			warnings = append(warnings, &Warning{Severity: SeverityMedium, Err: err})
+		}
+	}
+	return runtimes, joinWarnings(warnings)
+}
+
+// containerdDetector returns the built-in containerd detector in d's CRI slot,
+// directly or within a multiCRIDetector, or nil if there is none.
+func (d *Detector) containerdDetector() *ContainerdDetector {
+	detectors := []CRIDetector{d.cri}
+	if multi, ok := d.cri.(multiCRIDetector); ok {
+		detectors = multi
+	}
+	for _, cri := range detectors {
+		if containerd, ok := cri.(*ContainerdDetector); ok {
+			return containerd
+		}
+	}
+	return nil
+}
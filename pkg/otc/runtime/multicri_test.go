@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMultiCRIDetector_Detect(t *testing.T) {
+	t.Parallel()
+
+	containerd := Runtime{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}
+	crio := Runtime{Name: CRIO, Type: TypeCRI, Priority: PriorityCRI}
+	notFound := fmt.Errorf("crio socket %w", ErrRuntimeNotFound)
+	dialErr := errors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		detectors    multiCRIDetector
+		want         []string
+		wantErr      bool
+		wantWarnings bool
+	}{
+		{
+			name: "one found, other missing",
+			detectors: multiCRIDetector{
+				&countingCRIDetector{runtimes: []Runtime{containerd}},
+				&countingCRIDetector{err: notFound},
+			},
+			want: []string{Containerd},
+		},
+		{
+			name: "both found",
+			detectors: multiCRIDetector{
+				&countingCRIDetector{runtimes: []Runtime{containerd}},
+				&countingCRIDetector{runtimes: []Runtime{crio}},
+			},
+			want: []string{Containerd, CRIO},
+		},
+		{
+			name: "other failure kept as warning",
+			detectors: multiCRIDetector{
+				&countingCRIDetector{err: dialErr},
+				&countingCRIDetector{runtimes: []Runtime{crio}},
+			},
+			want:         []string{CRIO},
+			wantWarnings: true,
+		},
+		{
+			name: "detector warning passed on",
+			detectors: multiCRIDetector{
+				&countingCRIDetector{runtimes: []Runtime{containerd}, err: newWarning(SeverityLow, "slow socket")},
+			},
+			want:         []string{Containerd},
+			wantWarnings: true,
+		},
+		{
+			name: "none found",
+			detectors: multiCRIDetector{
+				&countingCRIDetector{err: dialErr},
+				&countingCRIDetector{err: notFound},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			runtimes, err := tt.detectors.Detect(context.Background())
+			if tt.wantErr {
+				if err == nil || isWarning(err) {
+					t.Fatalf("Detect() error = %v, want fatal error", err)
+				}
+				if !errors.Is(err, dialErr) || !errors.Is(err, ErrRuntimeNotFound) {
+					t.Errorf("Detect() error = %v, want both detector errors", err)
+				}
+				return
+			}
+
+			if tt.wantWarnings != (err != nil) {
+				t.Fatalf("Detect() error = %v, want warnings %v", err, tt.wantWarnings)
+			}
+			if err != nil && !isWarning(err) {
+				t.Errorf("Detect() error = %v, want a warning", err)
+			}
+
+			var got []string
+			for _, rt := range runtimes {
+				got = append(got, rt.Name)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Detect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package runtime
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// defaultNegativeTTL bounds how long an absent binary or socket is remembered
+const defaultNegativeTTL = 10 * time.Second
+
+// negativeCache remembers probe targets (binaries, sockets) that were absent,
+// so repeated detection can skip re-probing them. An entry is invalidated when
+// its TTL expires or when the modification time of any watched directory changes,
+// which happens when a binary is installed or a socket is created.
+//
+// A nil *negativeCache is valid and never caches anything.
+type negativeCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]negativeEntry
+}
+
+// negativeEntry is a cached absence with the directory state it depends on.
+type negativeEntry struct {
+	expires time.Time
+	dirs    map[string]time.Time // Directory -> mtime when recorded (zero if missing)
+}
+
+// newNegativeCache creates a negative cache with the given TTL.
+func newNegativeCache(ttl time.Duration) *negativeCache {
+	return &negativeCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]negativeEntry),
+	}
+}
+
+// absent reports whether key is known to be absent and the entry is still valid.
+func (c *negativeCache) absent(key string) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+
+	if !c.now().Before(entry.expires) || dirsChanged(entry.dirs) {
+		delete(c.entries, key)
+		return false
+	}
+
+	return true
+}
+
+// record remembers key as absent, watching dirs for changes.
+func (c *negativeCache) record(key string, dirs []string) {
+	if c == nil {
+		return
+	}
+
+	snapshot := make(map[string]time.Time, len(dirs))
+	for _, dir := range dirs {
+		snapshot[dir] = dirModTime(dir)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = negativeEntry{
+		expires: c.now().Add(c.ttl),
+		dirs:    snapshot,
+	}
+}
+
+// dirsChanged reports whether any directory's mtime differs from its snapshot.
+func dirsChanged(snapshot map[string]time.Time) bool {
+	for dir, mtime := range snapshot {
+		if !dirModTime(dir).Equal(mtime) {
+			return true
+		}
+	}
+	return false
+}
+
+// dirModTime returns a directory's modification time, or the zero time if it can't be read.
+func dirModTime(dir string) time.Time {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,117 @@
+package runtime
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNegativeCache(t *testing.T) {
+	t.Parallel()
+
+	// Pin the directory mtime in the past so later changes are always observable
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		act  func(t *testing.T, dir string, clock *time.Time)
+		want bool
+	}{
+		{
+			name: "cache hit",
+			act:  func(*testing.T, string, *time.Time) {},
+			want: true,
+		},
+		{
+			name: "expired entry",
+			act: func(_ *testing.T, _ string, clock *time.Time) {
+				*clock = clock.Add(defaultNegativeTTL)
+			},
+			want: false,
+		},
+		{
+			name: "directory change invalidates",
+			act: func(t *testing.T, dir string, _ *time.Time) {
+				listener, err := net.Listen("unix", filepath.Join(dir, "containerd.sock"))
+				if err != nil {
+					t.Fatalf("failed to create Unix socket: %v", err)
+				}
+				t.Cleanup(func() { _ = listener.Close() })
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			if err := os.Chtimes(dir, past, past); err != nil {
+				t.Fatalf("failed to set directory mtime: %v", err)
+			}
+
+			clock := time.Now()
+			cache := newNegativeCache(defaultNegativeTTL)
+			cache.now = func() time.Time { return clock }
+
+			key := filepath.Join(dir, "containerd.sock")
+			cache.record(key, []string{dir})
+
+			tt.act(t, dir, &clock)
+
+			if got := cache.absent(key); got != tt.want {
+				t.Errorf("absent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegativeCache_Nil(t *testing.T) {
+	t.Parallel()
+
+	var cache *negativeCache
+	cache.record("runc", nil)
+	if cache.absent("runc") {
+		t.Error("nil cache reported a cached absence")
+	}
+}
+
+func TestContainerdDetector_findSocket_NegativeCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(dir, past, past); err != nil {
+		t.Fatalf("failed to set directory mtime: %v", err)
+	}
+	socketPath := filepath.Join(dir, "containerd.sock")
+
+	detector := NewContainerdDetector()
+	detector.socketPaths = []string{socketPath}
+
+	if _, _, err := detector.findSocket(); err == nil {
+		t.Fatal("findSocket() expected error before socket exists")
+	}
+	if !detector.negative.absent(socketPath) {
+		t.Error("missing socket was not cached")
+	}
+
+	// Installing the runtime later must still be detected
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to create Unix socket: %v", err)
+	}
+	defer listener.Close()
+
+	got, _, err := detector.findSocket()
+	if err != nil {
+		t.Fatalf("findSocket() error = %v after socket created", err)
+	}
+	if got != socketPath {
+		t.Errorf("findSocket() = %q, want %q", got, socketPath)
+	}
+}
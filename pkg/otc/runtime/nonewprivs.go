@@ -0,0 +1,54 @@
+package runtime
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// checkNoNewPrivs warns when this process runs with no_new_privs set as a
+// non-root user while OCI runtimes are detected. Rootless containers depend on
+// the setuid newuidmap/newgidmap helpers to set up user namespaces, and
+// no_new_privs stops setuid binaries from gaining privileges, so containers
+// launched from this process (or its children) would fail to start.
+// Returns nil when the status cannot be read.
+func checkNoNewPrivs(runtimes []Runtime, procRoot string, euid int) []error {
+	if euid == 0 || !noNewPrivs(procRoot) {
+		return nil
+	}
+
+	var affected []string
+	for _, rt := range runtimes {
+		if rt.Type == TypeOCI {
+			affected = append(affected, rt.Name)
+		}
+	}
+	if len(affected) == 0 {
+		return nil
+	}
+
+	return []error{newWarning(SeverityHigh,
+		"no_new_privs is set for this non-root process; rootless %s cannot use setuid uid/gid mapping helpers",
+		strings.Join(affected, ", "))}
+}
+
+// noNewPrivs reports whether the NoNewPrivs flag is set in procRoot/self/status.
+func noNewPrivs(procRoot string) bool {
+	f, err := os.Open(filepath.Join(procRoot, "self", "status"))
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if ok && key == "NoNewPrivs" {
+			return strings.TrimSpace(value) == "1"
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package runtime
+
+import (
+	"testing"
+)
+
+func TestCheckNoNewPrivs(t *testing.T) {
+	t.Parallel()
+
+	oci := []Runtime{
+		{Name: Runc, Type: TypeOCI},
+		{Name: Containerd, Type: TypeCRI},
+	}
+
+	tests := []struct {
+		name        string
+		status      string
+		euid        int
+		runtimes    []Runtime
+		wantWarning bool
+	}{
+		{
+			name:        "rootless under no_new_privs",
+			status:      "Name:\totc\nUid:\t1000\t1000\t1000\t1000\nNoNewPrivs:\t1\nSeccomp:\t2\n",
+			euid:        1000,
+			runtimes:    oci,
+			wantWarning: true,
+		},
+		{
+			name:        "rootless without no_new_privs",
+			status:      "Name:\totc\nNoNewPrivs:\t0\n",
+			euid:        1000,
+			runtimes:    oci,
+			wantWarning: false,
+		},
+		{
+			name:        "root under no_new_privs",
+			status:      "Name:\totc\nNoNewPrivs:\t1\n",
+			euid:        0,
+			runtimes:    oci,
+			wantWarning: false,
+		},
+		{
+			name:        "no OCI runtimes",
+			status:      "Name:\totc\nNoNewPrivs:\t1\n",
+			euid:        1000,
+			runtimes:    []Runtime{{Name: Containerd, Type: TypeCRI}},
+			wantWarning: false,
+		},
+		{
+			name:        "kernel without the field",
+			status:      "Name:\totc\nSeccomp:\t0\n",
+			euid:        1000,
+			runtimes:    oci,
+			wantWarning: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			procRoot := t.TempDir()
+			writeProcEntry(t, procRoot, "self", map[string]string{"status": tt.status})
+
+			warnings := checkNoNewPrivs(tt.runtimes, procRoot, tt.euid)
+			if got := len(warnings) > 0; got != tt.wantWarning {
+				t.Fatalf("checkNoNewPrivs() = %v, wantWarning %v", warnings, tt.wantWarning)
+			}
+			if tt.wantWarning && WarningSeverity(warnings[0]) != SeverityHigh {
+				t.Errorf("severity = %v, want %v", WarningSeverity(warnings[0]), SeverityHigh)
+			}
+		})
+	}
+}
+
+func TestCheckNoNewPrivs_MissingStatus(t *testing.T) {
+	t.Parallel()
+
+	if warnings := checkNoNewPrivs([]Runtime{{Name: Runc, Type: TypeOCI}}, t.TempDir(), 1000); warnings != nil {
+		t.Errorf("checkNoNewPrivs() = %v, want nil", warnings)
+	}
+}
@@ -0,0 +1,43 @@
+package runtime
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultNVIDIAToolkitConfig is where the NVIDIA container toolkit stores its configuration
+const defaultNVIDIAToolkitConfig = "/etc/nvidia-container-runtime/config.toml"
+
+// nvidiaHandlerSuffix ends the name of an "nvidia" runtime handler table in containerd's
+// config, e.g. [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+const nvidiaHandlerSuffix = ".runtimes.nvidia"
+
+// nvidiaProbe checks whether a node is ready to run GPU containers end to end.
+type nvidiaProbe struct {
+	toolkitConfig string
+}
+
+// ready reports whether the NVIDIA container toolkit is installed and configured,
+// and whether the nvidia handler is registered in the given containerd config.
+// Each sub-check degrades gracefully: a missing binary or unreadable file means not ready.
+func (p nvidiaProbe) ready(containerdConfig string) bool {
+	if _, err := exec.LookPath("nvidia-ctk"); err != nil {
+		return false
+	}
+
+	if _, err := os.Stat(p.toolkitConfig); err != nil {
+		return false
+	}
+
+	entries, err := readTOMLEntries(containerdConfig)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if e.key == "" && strings.HasSuffix(e.table, nvidiaHandlerSuffix) {
+			return true
+		}
+	}
+	return false
+}
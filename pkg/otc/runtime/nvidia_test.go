@@ -0,0 +1,108 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFakeBinary creates an executable shell script named name in dir.
+func writeFakeBinary(t *testing.T, dir, name, script string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatalf("failed to write fake binary %s: %v", name, err)
+	}
+	return path
+}
+
+func TestNVIDIAProbe_Ready(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	const registered = `version = 2
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+  runtime_type = "io.containerd.runc.v2"
+`
+	const unregistered = `version = 2
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+  runtime_type = "io.containerd.runc.v2"
+`
+
+	tests := []struct {
+		name             string
+		withCTK          bool
+		withToolkit      bool
+		containerdConfig string
+		want             bool
+	}{
+		{
+			name:             "fully ready",
+			withCTK:          true,
+			withToolkit:      true,
+			containerdConfig: registered,
+			want:             true,
+		},
+		{
+			name:             "missing nvidia-ctk",
+			withCTK:          false,
+			withToolkit:      true,
+			containerdConfig: registered,
+			want:             false,
+		},
+		{
+			name:             "missing toolkit config",
+			withCTK:          true,
+			withToolkit:      false,
+			containerdConfig: registered,
+			want:             false,
+		},
+		{
+			name:             "handler not registered",
+			withCTK:          true,
+			withToolkit:      true,
+			containerdConfig: unregistered,
+			want:             false,
+		},
+		{
+			name:             "containerd config missing",
+			withCTK:          true,
+			withToolkit:      true,
+			containerdConfig: "",
+			want:             false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			binDir := filepath.Join(dir, "bin")
+			if err := os.Mkdir(binDir, 0755); err != nil {
+				t.Fatalf("failed to create bin dir: %v", err)
+			}
+			if tt.withCTK {
+				writeFakeBinary(t, binDir, "nvidia-ctk", "exit 0")
+			}
+			t.Setenv("PATH", binDir)
+
+			toolkitConfig := filepath.Join(dir, "nvidia-config.toml")
+			if tt.withToolkit {
+				if err := os.WriteFile(toolkitConfig, []byte("[nvidia-container-cli]\n"), 0644); err != nil {
+					t.Fatalf("failed to write toolkit config: %v", err)
+				}
+			}
+
+			containerdConfig := filepath.Join(dir, "config.toml")
+			if tt.containerdConfig != "" {
+				if err := os.WriteFile(containerdConfig, []byte(tt.containerdConfig), 0644); err != nil {
+					t.Fatalf("failed to write containerd config: %v", err)
+				}
+			}
+
+			probe := nvidiaProbe{toolkitConfig: toolkitConfig}
+			if got := probe.ready(containerdConfig); got != tt.want {
+				t.Errorf("ready() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,92 +1,400 @@
 package runtime
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 )
 
+// unknownVersion is reported for runtimes kept despite an unparseable version.
+const unknownVersion = "unknown"
+
+// errUnparseableVersion marks --version output no parser understood.
+var errUnparseableVersion = errors.New("unparseable version output")
+
+// defaultOCIRuntimeNames are the binaries NewOCIDetector searches PATH for
+var defaultOCIRuntimeNames = []string{Runc, Crun, Youki, Runsc}
+
+// maxOCIProbes bounds how many OCI runtime binaries are probed at once
+const maxOCIProbes = 4
+
 // ociDetector implements OCIDetector for finding OCI runtime binaries.
-type ociDetector struct{}
+type ociDetector struct {
+	names       []string       // Binaries to search PATH for, in order
+	namesOnly   bool           // Probe only names, skipping the wasmedge probe
+	negative    *negativeCache // Binaries recently found missing from PATH
+	softVersion bool           // Keep runtimes whose version can't be parsed
+	run         commandRunner  // Runs probe commands; nil means execOutput
+	procRoot    string         // Root of the proc filesystem, for kernel checks
+	cgroupRoot  string         // Mount point of the cgroup filesystem; empty skips the check
+	logger      *slog.Logger   // Debug tracing; nil logs nothing
+}
+
+// commandRunner executes a command and returns its standard output.
+// It lets probes be tested against canned output instead of real binaries.
+type commandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+// execOutput is the default commandRunner.
+func execOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
+
+// runner returns the configured commandRunner, defaulting to execOutput.
+func (d *ociDetector) runner() commandRunner {
+	if d.run != nil {
+		return d.run
+	}
+	return execOutput
+}
+
+// setLogger sets the logger used to trace binary lookups and version parsing.
+func (d *ociDetector) setLogger(logger *slog.Logger) {
+	d.logger = logger
+}
+
+// OCIOption configures the OCI detector.
+type OCIOption func(*ociDetector)
+
+// WithSoftVersionErrors keeps runtimes whose `--version` output cannot be parsed,
+// reporting them with Version "unknown" instead of dropping them. Detect then
+// returns the kept runtimes along with a low-severity *Warning naming them.
+// Runtimes whose `--version` fails to execute are still dropped.
+func WithSoftVersionErrors() OCIOption {
+	return func(d *ociDetector) {
+		d.softVersion = true
+	}
+}
 
 // NewOCIDetector creates a new OCI runtime detector.
-func NewOCIDetector() OCIDetector {
-	return &ociDetector{}
+// Binaries missing from PATH are remembered briefly and skipped on repeated
+// detection until the TTL expires or a PATH directory changes.
+func NewOCIDetector(opts ...OCIOption) OCIDetector {
+	d := &ociDetector{
+		names:      defaultOCIRuntimeNames,
+		negative:   newNegativeCache(defaultNegativeTTL),
+		procRoot:   "/proc",
+		cgroupRoot: defaultCgroupRoot,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// NewOCIDetectorWithNames creates an OCI runtime detector that searches PATH
+// for exactly the given binaries instead of runc, crun, youki, and runsc, e.g.
+// to include sandboxed runtimes like kata-runtime. Runtimes are reported under
+// their binary name with the OCI type and priority, and versions are parsed
+// like the built-in ones. The standalone wasmedge probe is skipped. With no
+// names, the default set is used, wasmedge included.
+func NewOCIDetectorWithNames(names ...string) OCIDetector {
+	d := NewOCIDetector().(*ociDetector)
+	if len(names) > 0 {
+		d.names = append([]string(nil), names...)
+		d.namesOnly = true
+	}
+	return d
 }
 
 // Detect finds all available OCI runtime binaries in system PATH.
-// It searches for runc, crun, and youki executables.
-func (d *ociDetector) Detect() ([]Runtime, error) {
-	runtimeNames := []string{"runc", "crun", "youki"}
+// It searches for runc, crun, youki, and runsc executables, and reports a
+// standalone wasmedge binary as an informational Wasm runtime. A detector
+// from NewOCIDetectorWithNames searches for its names only.
+func (d *ociDetector) Detect(ctx context.Context) ([]Runtime, error) {
 	var found []Runtime
+	var unparsed []string
+	var warnings []error
 
-	for _, name := range runtimeNames {
-		runtime, err := d.detectRuntime(name)
-		if err != nil {
+	for _, probe := range d.probeAll(ctx) {
+		if errors.Is(probe.err, errNotExecutable) {
+			// A binary without the execute bit is a broken install worth reporting
+			warnings = append(warnings, &Warning{Severity: SeverityMedium, Err: probe.err})
+			continue
+		}
+		if probe.err != nil {
 			// Binary not found or not accessible - this is normal, continue
 			continue
 		}
-		found = append(found, runtime)
+		if probe.runtime.Version == unknownVersion {
+			unparsed = append(unparsed, probe.runtime.Name)
+		}
+		found = append(found, probe.runtime)
+	}
+
+	if !d.namesOnly {
+		if runtime, err := d.detectWasmEdge(ctx); err == nil {
+			found = append(found, runtime)
+		}
+	}
+
+	if len(unparsed) > 0 {
+		warnings = append(warnings, newWarning(SeverityLow,
+			"kept runtimes with unparseable versions: %s", strings.Join(unparsed, ", ")))
+	}
+	return found, joinWarnings(warnings)
+}
+
+// errNotExecutable reports an OCI runtime binary in PATH that the current
+// user can't execute, e.g. one extracted without its permission bits.
+var errNotExecutable = errors.New("not executable")
+
+// nonExecutableInPath returns the first regular file named name in the
+// directories of pathEnv, or "" if there is none. exec.LookPath skips files the
+// current user can't execute, so after it fails such a file is a binary that
+// is installed but not executable.
+func nonExecutableInPath(name, pathEnv string) string {
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path
+		}
 	}
+	return ""
+}
 
-	return found, nil
+// ociProbe is the outcome of detecting one OCI runtime binary.
+type ociProbe struct {
+	runtime Runtime
+	err     error
+}
+
+// probeAll detects each of d.names concurrently, at most maxOCIProbes at a
+// time, so slow version commands don't serialize. Results are in d.names order.
+func (d *ociDetector) probeAll(ctx context.Context) []ociProbe {
+	probes := make([]ociProbe, len(d.names))
+	sem := make(chan struct{}, maxOCIProbes)
+
+	var wg sync.WaitGroup
+	for i, name := range d.names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			// Each goroutine writes only its own element
+			probes[i].runtime, probes[i].err = d.detectRuntime(ctx, name)
+		}()
+	}
+	wg.Wait()
+	return probes
 }
 
 // detectRuntime attempts to find and query a specific OCI runtime.
-func (d *ociDetector) detectRuntime(name string) (Runtime, error) {
+func (d *ociDetector) detectRuntime(ctx context.Context, name string) (Runtime, error) {
+	// Skip binaries recently found missing, keyed by PATH so edits re-probe
+	pathEnv := os.Getenv("PATH")
+	cacheKey := name + "\x00" + pathEnv
+	if d.negative.absent(cacheKey) {
+		loggerOrDiscard(d.logger).Debug("binary skipped", "runtime", name, "reason", skipRecentlyMissing)
+		return Runtime{}, fmt.Errorf("runtime %s %w in PATH: %w", name, ErrRuntimeNotFound, exec.ErrNotFound)
+	}
+
 	// Find binary in PATH
 	path, err := exec.LookPath(name)
 	if err != nil {
-		return Runtime{}, fmt.Errorf("runtime %s not found in PATH: %w", name, err)
+		// Not negative-cached: fixing the permission doesn't change directory mtimes
+		if path := nonExecutableInPath(name, pathEnv); path != "" {
+			loggerOrDiscard(d.logger).Debug("binary not executable", "runtime", name, "path", path)
+			return Runtime{}, fmt.Errorf("found %s at %s but it is %w", name, path, errNotExecutable)
+		}
+		d.negative.record(cacheKey, filepath.SplitList(pathEnv))
+		loggerOrDiscard(d.logger).Debug("binary not found in PATH", "runtime", name)
+		return Runtime{}, fmt.Errorf("runtime %s %w in PATH: %w", name, ErrRuntimeNotFound, err)
 	}
 
+	return d.probeBinary(ctx, name, path)
+}
+
+// probeBinary queries the OCI runtime binary at path for its version and capabilities.
+func (d *ociDetector) probeBinary(ctx context.Context, name, path string) (Runtime, error) {
 	// Extract version
-	version, err := d.extractVersion(name, path)
+	info, err := d.extractVersion(ctx, name, path)
+	loggerOrDiscard(d.logger).Debug("version parsed", "runtime", name, "path", path, "version", info.version, "error", err)
+	if d.softVersion && errors.Is(err, errUnparseableVersion) {
+		info, err = versionInfo{version: unknownVersion}, nil
+	}
 	if err != nil {
 		return Runtime{}, fmt.Errorf("failed to get version for %s: %w", name, err)
 	}
 
+	// Probe optional features; older runtimes lack the subcommand and report none
+	features, _ := probeFeatures(ctx, d.runner(), path)
+	caps := mergeCapabilities(info.caps, features)
+	caps = mergeCapabilities(caps, probeELF(path))
+	caps = mergeCapabilities(caps, probeSystemdCgroupFlag(ctx, d.runner(), path))
+	caps = mergeCapabilities(caps, probeSdNotify(ctx, d.runner(), path))
+
+	if supported, ok := ociRROMounts(caps, d.procRoot); ok {
+		caps["rroMounts"] = strconv.FormatBool(supported)
+	}
+
+	// Host-global, but it decides how this runtime applies resource limits
+	var cgroups int
+	if d.cgroupRoot != "" {
+		cgroups, _ = cgroupVersion(d.cgroupRoot)
+	}
+
 	return Runtime{
-		Name:     name,
-		Type:     TypeOCI,
-		Version:  version,
-		Path:     path,
-		Priority: PriorityOCI,
+		Name:          name,
+		Type:          TypeOCI,
+		Version:       info.version,
+		Path:          path,
+		Priority:      PriorityOCI,
+		Commit:        info.commit,
+		SpecVersion:   info.specVersion,
+		CgroupVersion: cgroups,
+		Capabilities:  caps,
 	}, nil
 }
 
-// extractVersion executes `<runtime> --version` and parses the output.
-func (d *ociDetector) extractVersion(name, path string) (string, error) {
-	cmd := exec.Command(path, "--version")
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+// versionInfo is the parsed output of `<runtime> --version`.
+type versionInfo struct {
+	version     string
+	commit      string
+	specVersion string
+	caps        map[string]string
+}
 
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to execute %s --version: %w (stderr: %s)",
-			name, err, stderr.String())
+// extractVersion executes `<runtime> --version` and parses the output
+// with the runtime's registered VersionParser.
+func (d *ociDetector) extractVersion(ctx context.Context, name, path string) (versionInfo, error) {
+	stdout, err := d.runner()(ctx, path, "--version")
+	if err != nil {
+		var stderr []byte
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			stderr = exitErr.Stderr
+		}
+		return versionInfo{}, fmt.Errorf("failed to execute %s --version: %w (stderr: %s)",
+			name, err, stderr)
 	}
 
 	// Parse version from output
-	output := stdout.String()
-	version := parseVersion(output)
+	output := string(stdout)
+	version, caps := versionParserFor(name)(output)
 	if version == "" {
-		return "", fmt.Errorf("failed to parse version from output: %s", output)
+		return versionInfo{}, fmt.Errorf("%w: %s", errUnparseableVersion, output)
 	}
 
-	return version, nil
+	return versionInfo{
+		version:     version,
+		commit:      parseCommit(output),
+		specVersion: parseSpecVersion(output),
+		caps:        caps,
+	}, nil
 }
 
 // parseVersion extracts version string from runtime --version output.
 // All OCI runtimes (runc, crun, youki) output format: "<name> version <version> ..."
+// A leading "v" or "V" is stripped so "v1.1.12" and "1.1.12" compare equal.
 func parseVersion(output string) string {
 	// Split by whitespace and find "version" keyword
 	fields := strings.Fields(output)
 	for i, field := range fields {
 		if strings.EqualFold(field, "version") && i+1 < len(fields) {
-			return fields[i+1]
+			return stripVersionPrefix(fields[i+1])
 		}
 	}
 	return ""
 }
+
+// stripVersionPrefix removes a single leading "v" or "V" when a numeric
+// version follows, keeping pre-release and build metadata intact.
+func stripVersionPrefix(version string) string {
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') &&
+		version[1] >= '0' && version[1] <= '9' {
+		return version[1:]
+	}
+	return version
+}
+
+// parseCommit extracts the "commit: <rev>" line common to runc, crun, and youki.
+// Returns empty string if the banner has no commit line.
+func parseCommit(output string) string {
+	return bannerFields(output)["commit"]
+}
+
+// parseSpecVersion extracts the "spec: <version>" line reporting the OCI
+// runtime spec version, printed by runc and runsc.
+// Returns empty string if the banner has no spec line.
+func parseSpecVersion(output string) string {
+	return bannerFields(output)["spec"]
+}
+
+// bannerFields collects "key: value" lines from --version output.
+// Keys are lowercased and trimmed.
+func bannerFields(output string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	return fields
+}
+
+// mergeCapabilities copies src into dst, allocating dst if needed.
+// Returns nil if both are empty.
+func mergeCapabilities(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		if len(dst) == 0 {
+			return nil
+		}
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// probeSystemdCgroupFlag reports whether the runtime accepts --systemd-cgroup,
+// found by searching its `--help` output, in Capabilities["systemdCgroupFlag"].
+// Returns nil when the help output cannot be obtained.
+func probeSystemdCgroupFlag(ctx context.Context, run commandRunner, path string) map[string]string {
+	output, err := run(ctx, path, "--help")
+	if err != nil {
+		return nil
+	}
+	supported := strings.Contains(string(output), "--systemd-cgroup")
+	return map[string]string{"systemdCgroupFlag": strconv.FormatBool(supported)}
+}
+
+// sdNotifyMarkers are strings in `<runtime> run --help` output that indicate
+// systemd notify integration: runc's --no-subreaper (used when relaying
+// sd_notify), crun's --notify-socket, or documentation of NOTIFY_SOCKET.
+var sdNotifyMarkers = []string{"--no-subreaper", "--notify-socket", "NOTIFY_SOCKET"}
+
+// probeSdNotify reports whether the runtime supports relaying sd_notify from
+// containers, found by searching its `run --help` output, in Capabilities["sdNotify"].
+// Returns nil when the help output cannot be obtained.
+func probeSdNotify(ctx context.Context, run commandRunner, path string) map[string]string {
+	output, err := run(ctx, path, "run", "--help")
+	if err != nil {
+		return nil
+	}
+	supported := false
+	for _, marker := range sdNotifyMarkers {
+		if strings.Contains(string(output), marker) {
+			supported = true
+			break
+		}
+	}
+	return map[string]string{"sdNotify": strconv.FormatBool(supported)}
+}
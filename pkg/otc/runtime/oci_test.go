@@ -1,7 +1,16 @@
 package runtime
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestOCIDetector_Detect(t *testing.T) {
@@ -25,7 +34,7 @@ func TestOCIDetector_Detect(t *testing.T) {
 			t.Parallel()
 
 			detector := NewOCIDetector()
-			runtimes, err := detector.Detect()
+			runtimes, err := detector.Detect(context.Background())
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Detect() error = %v, wantErr %v", err, tt.wantErr)
@@ -37,9 +46,13 @@ func TestOCIDetector_Detect(t *testing.T) {
 				if rt.Name == "" {
 					t.Error("Runtime has empty Name")
 				}
-				if rt.Type != TypeOCI {
+				wantType, wantPriority := TypeOCI, PriorityOCI
+				if rt.Name == WasmEdge {
+					wantType, wantPriority = TypeWasm, PriorityWasm
+				}
+				if rt.Type != wantType {
 					t.Errorf("Runtime %s has wrong Type: got %v, want %v",
-						rt.Name, rt.Type, TypeOCI)
+						rt.Name, rt.Type, wantType)
 				}
 				if rt.Version == "" {
 					t.Errorf("Runtime %s has empty Version", rt.Name)
@@ -47,9 +60,9 @@ func TestOCIDetector_Detect(t *testing.T) {
 				if rt.Path == "" {
 					t.Errorf("Runtime %s has empty Path", rt.Name)
 				}
-				if rt.Priority != PriorityOCI {
+				if rt.Priority != wantPriority {
 					t.Errorf("Runtime %s has wrong Priority: got %d, want %d",
-						rt.Name, rt.Priority, PriorityOCI)
+						rt.Name, rt.Priority, wantPriority)
 				}
 			}
 		})
@@ -89,6 +102,26 @@ func TestParseVersion(t *testing.T) {
 			output: "runtime version 1.2.3-rc1+git.abcdef",
 			want:   "1.2.3-rc1+git.abcdef",
 		},
+		{
+			name:   "leading lowercase v",
+			output: "runc version v1.1.12",
+			want:   "1.1.12",
+		},
+		{
+			name:   "leading uppercase V",
+			output: "runtime version V2.0.0",
+			want:   "2.0.0",
+		},
+		{
+			name:   "leading v with pre-release",
+			output: "runtime version v1.2.3-rc1+git.abcdef",
+			want:   "1.2.3-rc1+git.abcdef",
+		},
+		{
+			name:   "v not followed by a number",
+			output: "runtime version vendor-build",
+			want:   "vendor-build",
+		},
 		{
 			name:   "no version keyword",
 			output: "runtime 1.2.3",
@@ -129,8 +162,445 @@ func TestOCIDetector_DetectRuntime_Integration(t *testing.T) {
 	detector := &ociDetector{}
 
 	// Test with a runtime that definitely doesn't exist
-	_, err := detector.detectRuntime("nonexistent-runtime-xyz123")
+	_, err := detector.detectRuntime(context.Background(), "nonexistent-runtime-xyz123")
 	if err == nil {
 		t.Error("detectRuntime() expected error for nonexistent runtime, got nil")
 	}
 }
+
+func TestParseCommit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "runc banner",
+			output: "runc version 1.1.12\ncommit: v1.1.12-0-g51d5e94\nspec: 1.0.2-dev\ngo: go1.20.13\nlibseccomp: 2.5.4",
+			want:   "v1.1.12-0-g51d5e94",
+		},
+		{
+			name:   "no commit line",
+			output: "crun version 1.8.7",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := parseCommit(tt.output); got != tt.want {
+				t.Errorf("parseCommit() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOCIDetector_Detect_SoftVersionErrors(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	writeFakeBinary(t, binDir, Runc, `echo "runc: build 2024-05-01 (dev)"`)
+	t.Setenv("PATH", binDir)
+
+	tests := []struct {
+		name        string
+		opts        []OCIOption
+		wantVersion string
+		wantWarning bool
+	}{
+		{
+			name:        "strict drops runtime",
+			opts:        nil,
+			wantVersion: "",
+			wantWarning: false,
+		},
+		{
+			name:        "soft keeps runtime",
+			opts:        []OCIOption{WithSoftVersionErrors()},
+			wantVersion: unknownVersion,
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtimes, err := NewOCIDetector(tt.opts...).Detect(context.Background())
+
+			if !tt.wantWarning {
+				if err != nil {
+					t.Fatalf("Detect() error = %v, want nil", err)
+				}
+				if len(runtimes) != 0 {
+					t.Errorf("Detect() = %+v, want no runtimes", runtimes)
+				}
+				return
+			}
+
+			if !isWarning(err) || WarningSeverity(err) != SeverityLow {
+				t.Fatalf("Detect() error = %v, want low-severity warning", err)
+			}
+			if len(runtimes) != 1 || runtimes[0].Name != Runc {
+				t.Fatalf("Detect() = %+v, want only runc", runtimes)
+			}
+			if runtimes[0].Version != tt.wantVersion {
+				t.Errorf("Version = %q, want %q", runtimes[0].Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestProbeSystemdCgroupFlag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		output string
+		err    error
+		want   map[string]string
+	}{
+		{
+			name: "runc help with flag",
+			output: `GLOBAL OPTIONS:
+   --debug             enable debug logging
+   --root value        root directory for storage of container state
+   --systemd-cgroup    enable systemd cgroup support
+`,
+			want: map[string]string{"systemdCgroupFlag": "true"},
+		},
+		{
+			name: "help without flag",
+			output: `Options:
+  --debug     produce verbose output
+  --root=DIR  root directory
+`,
+			want: map[string]string{"systemdCgroupFlag": "false"},
+		},
+		{
+			name: "help fails",
+			err:  errors.New("exit status 1"),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotArgs []string
+			run := func(_ context.Context, name string, args ...string) ([]byte, error) {
+				gotArgs = append([]string{name}, args...)
+				return []byte(tt.output), tt.err
+			}
+
+			got := probeSystemdCgroupFlag(context.Background(), run, "/usr/bin/runc")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("probeSystemdCgroupFlag() = %v, want %v", got, tt.want)
+			}
+			if want := []string{"/usr/bin/runc", "--help"}; !reflect.DeepEqual(gotArgs, want) {
+				t.Errorf("ran %v, want %v", gotArgs, want)
+			}
+		})
+	}
+}
+
+func TestProbeSdNotify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		output string
+		err    error
+		want   map[string]string
+	}{
+		{
+			name: "runc run help",
+			output: `OPTIONS:
+   --bundle value, -b value  path to the root of the bundle directory
+   --no-subreaper            disable the use of the subreaper used to reap reparented processes
+   --no-pivot                do not use pivot root to jail process inside rootfs
+`,
+			want: map[string]string{"sdNotify": "true"},
+		},
+		{
+			name:   "notify socket option",
+			output: "  --notify-socket=PATH  forward sd_notify messages from the container\n",
+			want:   map[string]string{"sdNotify": "true"},
+		},
+		{
+			name:   "no notify support",
+			output: "Usage: runtime run [OPTIONS] CONTAINER\n  --bundle=DIR  path to the bundle\n",
+			want:   map[string]string{"sdNotify": "false"},
+		},
+		{
+			name: "help fails",
+			err:  errors.New("exit status 1"),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotArgs []string
+			run := func(_ context.Context, name string, args ...string) ([]byte, error) {
+				gotArgs = append([]string{name}, args...)
+				return []byte(tt.output), tt.err
+			}
+
+			if got := probeSdNotify(context.Background(), run, "/usr/bin/runc"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("probeSdNotify() = %v, want %v", got, tt.want)
+			}
+			if want := []string{"/usr/bin/runc", "run", "--help"}; !reflect.DeepEqual(gotArgs, want) {
+				t.Errorf("ran %v, want %v", gotArgs, want)
+			}
+		})
+	}
+}
+
+func TestNewOCIDetectorWithNames(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	writeFakeBinary(t, binDir, Runc, `echo "runc version 1.1.12"`)
+	writeFakeBinary(t, binDir, "custom-oci", `echo "custom-oci version 3.2.0"`)
+	writeFakeBinary(t, binDir, "crun-vm", `echo "crun-vm version 0.2.0"`)
+	writeFakeBinary(t, binDir, WasmEdge, `echo "wasmedge version 0.14.1"`)
+	t.Setenv("PATH", binDir)
+
+	tests := []struct {
+		name  string
+		names []string
+		want  map[string]string // Runtime name to version
+	}{
+		{
+			name:  "custom names skip wasmedge",
+			names: []string{"crun-vm", "custom-oci"},
+			want:  map[string]string{"crun-vm": "0.2.0", "custom-oci": "3.2.0"},
+		},
+		{
+			name:  "no names uses defaults",
+			names: nil,
+			want:  map[string]string{Runc: "1.1.12", WasmEdge: "0.14.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtimes, err := NewOCIDetectorWithNames(tt.names...).Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			got := make(map[string]string)
+			for _, rt := range runtimes {
+				if rt.Name != WasmEdge && (rt.Type != TypeOCI || rt.Priority != PriorityOCI) {
+					t.Errorf("%s: Type = %s, Priority = %d, want OCI defaults", rt.Name, rt.Type, rt.Priority)
+				}
+				got[rt.Name] = rt.Version
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Detect() versions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOCIDetector_Detect_Runsc(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	writeFakeBinary(t, binDir, Runsc, `echo "runsc version release-20240101.0"; echo "spec: 1.1.0"`)
+	t.Setenv("PATH", binDir)
+
+	runtimes, err := NewOCIDetector().Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(runtimes) != 1 {
+		t.Fatalf("Detect() = %+v, want only runsc", runtimes)
+	}
+
+	rt := runtimes[0]
+	if rt.Name != Runsc || rt.Type != TypeOCI {
+		t.Errorf("Detect() = %s (%s), want runsc (oci)", rt.Name, rt.Type)
+	}
+	if rt.Version != "release-20240101.0" {
+		t.Errorf("Version = %q, want %q", rt.Version, "release-20240101.0")
+	}
+	if rt.SpecVersion != "1.1.0" {
+		t.Errorf("SpecVersion = %q, want %q", rt.SpecVersion, "1.1.0")
+	}
+	if got := rt.Capabilities["releaseDate"]; got != "2024-01-01" {
+		t.Errorf("releaseDate = %q, want %q", got, "2024-01-01")
+	}
+}
+
+func TestOCIDetector_Detect_HungBinary(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	writeFakeBinary(t, binDir, Runc, `exec sleep 30`)
+	writeFakeBinary(t, binDir, Crun, `echo "crun version 1.14.4"`)
+	t.Setenv("PATH", binDir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	runtimes, err := NewOCIDetector().Detect(ctx)
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("Detect() took %v, want the hung binary killed at the deadline", elapsed)
+	}
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	for _, rt := range runtimes {
+		if rt.Name == Runc {
+			t.Errorf("Detect() reported hung runc: %+v", rt)
+		}
+	}
+}
+
+func TestOCIDetector_ProbeBinary_Runner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("commands go through the runner", func(t *testing.T) {
+		t.Parallel()
+
+		var mu sync.Mutex
+		var calls []string
+		run := func(_ context.Context, name string, args ...string) ([]byte, error) {
+			mu.Lock()
+			calls = append(calls, strings.Join(args, " "))
+			mu.Unlock()
+			switch strings.Join(args, " ") {
+			case "--version":
+				return []byte("runc version 1.1.12\nspec: 1.2.0\n"), nil
+			case "features":
+				return []byte(`{"ociVersionMin": "1.0.0", "ociVersionMax": "1.2.0"}`), nil
+			default:
+				return nil, errors.New("unsupported")
+			}
+		}
+
+		d := &ociDetector{run: run}
+		rt, err := d.probeBinary(context.Background(), Runc, "/opt/fake/runc")
+		if err != nil {
+			t.Fatalf("probeBinary() error = %v", err)
+		}
+		if rt.Version != "1.1.12" || rt.Capabilities["ociVersionMax"] != "1.2.0" {
+			t.Errorf("probeBinary() = %+v, want version 1.1.12 with features", rt)
+		}
+		for _, want := range []string{"--version", "features"} {
+			if !slices.Contains(calls, want) {
+				t.Errorf("runner calls = %q, want %q among them", calls, want)
+			}
+		}
+	})
+
+	t.Run("context deadline stops the probe", func(t *testing.T) {
+		t.Parallel()
+
+		run := func(ctx context.Context, _ string, _ ...string) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		d := &ociDetector{run: run}
+		if _, err := d.probeBinary(ctx, Runc, "/opt/fake/runc"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("probeBinary() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
+
+func TestOCIDetector_Detect_Parallel(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	marks := t.TempDir()
+
+	// Each binary waits for the others to start, so it only succeeds if all
+	// three are probed at once
+	names := []string{Runc, Crun, Youki}
+	for _, name := range names {
+		writeFakeBinary(t, binDir, name, `touch "`+marks+`/`+name+`"
+for i in $(seq 100); do
+	[ -e "`+marks+`/runc" ] && [ -e "`+marks+`/crun" ] && [ -e "`+marks+`/youki" ] && echo "`+name+` version 1.0.0" && exit 0
+	sleep 0.05
+done
+exit 1`)
+	}
+	// Keep the system PATH for touch, seq, and sleep; binDir shadows any real runtimes
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	runtimes, err := NewOCIDetectorWithNames(names...).Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	var got []string
+	for _, rt := range runtimes {
+		got = append(got, rt.Name)
+	}
+	if !reflect.DeepEqual(got, names) {
+		t.Errorf("Detect() = %v, want %v in name order", got, names)
+	}
+}
+
+func TestOCIDetector_Detect_NotExecutable(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	runcPath := filepath.Join(binDir, Runc)
+	if err := os.WriteFile(runcPath, []byte("#!/bin/sh\necho \"runc version 1.1.12\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", runcPath, err)
+	}
+	writeFakeBinary(t, binDir, Crun, `echo "crun version 1.14.4"`)
+	t.Setenv("PATH", binDir)
+
+	oci := NewOCIDetector()
+	result, err := NewDetector(oci, nil, nil, WithOverride("")).Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if result.Selected == nil || result.Selected.Name != Crun {
+		t.Fatalf("Selected = %v, want crun", result.Selected)
+	}
+
+	want := "found runc at " + runcPath + " but it is not executable"
+	var found bool
+	for _, warning := range result.Warnings {
+		if warning.Error() == want {
+			found = true
+			if WarningSeverity(warning) != SeverityMedium {
+				t.Errorf("WarningSeverity() = %v, want %v", WarningSeverity(warning), SeverityMedium)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Warnings = %v, want %q", result.Warnings, want)
+	}
+
+	// Fixing the permission is noticed on the next detection
+	if err := os.Chmod(runcPath, 0755); err != nil {
+		t.Fatalf("failed to chmod %s: %v", runcPath, err)
+	}
+	runtimes, err := oci.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(runtimes) != 2 || runtimes[0].Name != Runc {
+		t.Errorf("Detect() after chmod = %v, want runc and crun", runtimes)
+	}
+}
@@ -0,0 +1,137 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// daemonOOMScoreAdj returns the OOM score adjustment of the named daemon.
+// The live value from the running process is preferred; the oom_score from
+// the daemon's config file is the fallback. socketPath, the daemon's listening
+// socket, picks the process when several share the name (see daemonPID).
+// Returns "" when neither is discoverable.
+func daemonOOMScoreAdj(procRoot, comm, socketPath, configPath string) string {
+	if pid := daemonPID(procRoot, comm, socketPath); pid != "" {
+		data, err := os.ReadFile(filepath.Join(procRoot, pid, "oom_score_adj"))
+		if err == nil {
+			return strings.TrimSpace(string(data))
+		}
+	}
+
+	// Only the top-level oom_score applies to the daemon itself
+	entries, err := readTOMLEntries(configPath)
+	if err != nil {
+		return ""
+	}
+	for _, e := range entries {
+		if e.table != "" || e.key != "oom_score" {
+			continue
+		}
+		if _, err := strconv.Atoi(e.value); err == nil {
+			return e.value
+		}
+	}
+	return ""
+}
+
+// daemonPID returns the PID of the process named comm. When several match,
+// e.g. a rootful and a rootless containerd, the one holding the listening
+// socket at socketPath is returned. Returns "" if no process matches or the
+// matches can't be told apart, rather than guessing.
+func daemonPID(procRoot, comm, socketPath string) string {
+	pids := findDaemonPIDs(procRoot, comm)
+	if len(pids) == 1 {
+		return pids[0]
+	}
+
+	inode := unixSocketInode(procRoot, socketPath)
+	if inode == "" {
+		return ""
+	}
+	for _, pid := range pids {
+		if holdsSocket(procRoot, pid, inode) {
+			return pid
+		}
+	}
+	return ""
+}
+
+// findDaemonPIDs scans procRoot for processes whose command name is comm.
+// Returns nil if no matching process is visible.
+func findDaemonPIDs(procRoot, comm string) []string {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil
+	}
+
+	var pids []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || strings.Trim(name, "0123456789") != "" {
+			continue // Not a process directory
+		}
+
+		data, err := os.ReadFile(filepath.Join(procRoot, name, "comm"))
+		if err != nil {
+			continue // Process exited or not readable
+		}
+		if strings.TrimSpace(string(data)) == comm {
+			pids = append(pids, name)
+		}
+	}
+
+	return pids
+}
+
+// unixSocketListening is the /proc/net/unix Flags value of a listening socket.
+const unixSocketListening = "00010000"
+
+// unixSocketInode returns the inode of the listening Unix socket bound to path,
+// from procRoot/net/unix. Returns "" if path is empty or no such socket is listed.
+func unixSocketInode(procRoot, path string) string {
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(procRoot, "net", "unix"))
+	if err != nil {
+		return ""
+	}
+
+	// The socket is listed under the path it was bound to, e.g. /run rather than /var/run
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		resolved = path
+	}
+
+	// Fields: Num RefCount Protocol Flags Type St Inode Path
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 8 || fields[3] != unixSocketListening {
+			continue
+		}
+		if fields[7] == path || fields[7] == resolved {
+			return fields[6]
+		}
+	}
+	return ""
+}
+
+// holdsSocket reports whether process pid has an open descriptor for the
+// socket with the given inode.
+func holdsSocket(procRoot, pid, inode string) bool {
+	fdDir := filepath.Join(procRoot, pid, "fd")
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return false // Exited, or another user's process
+	}
+
+	want := "socket:[" + inode + "]"
+	for _, entry := range entries {
+		if target, err := os.Readlink(filepath.Join(fdDir, entry.Name())); err == nil && target == want {
+			return true
+		}
+	}
+	return false
+}
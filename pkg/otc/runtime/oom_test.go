@@ -0,0 +1,146 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeProcEntry creates a fake /proc/<pid> directory with the given files.
+func writeProcEntry(t *testing.T, procRoot, pid string, files map[string]string) {
+	t.Helper()
+
+	dir := filepath.Join(procRoot, pid)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create proc dir: %v", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write proc file %s: %v", name, err)
+		}
+	}
+}
+
+// writeProcSocket lists a listening Unix socket bound to path in procRoot/net/unix
+// and gives process pid a descriptor for it.
+func writeProcSocket(t *testing.T, procRoot, pid, inode, path string) {
+	t.Helper()
+
+	netDir := filepath.Join(procRoot, "net")
+	if err := os.MkdirAll(netDir, 0755); err != nil {
+		t.Fatalf("failed to create net dir: %v", err)
+	}
+	f, err := os.OpenFile(filepath.Join(netDir, "unix"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open net/unix: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString("0000000000000000: 00000002 00000000 00010000 0001 01 " + inode + " " + path + "\n"); err != nil {
+		t.Fatalf("failed to write net/unix: %v", err)
+	}
+
+	fdDir := filepath.Join(procRoot, pid, "fd")
+	if err := os.MkdirAll(fdDir, 0755); err != nil {
+		t.Fatalf("failed to create fd dir: %v", err)
+	}
+	if err := os.Symlink("socket:["+inode+"]", filepath.Join(fdDir, "3")); err != nil {
+		t.Fatalf("failed to create fd link: %v", err)
+	}
+}
+
+func TestDaemonOOMScoreAdj(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, procRoot, configPath string)
+		want  string
+	}{
+		{
+			name: "live process value",
+			setup: func(t *testing.T, procRoot, configPath string) {
+				writeProcEntry(t, procRoot, "1", map[string]string{"comm": "systemd\n", "oom_score_adj": "0\n"})
+				writeProcEntry(t, procRoot, "812", map[string]string{"comm": "containerd\n", "oom_score_adj": "-999\n"})
+				if err := os.WriteFile(configPath, []byte("oom_score = -500\n"), 0644); err != nil {
+					t.Fatalf("failed to write config: %v", err)
+				}
+			},
+			want: "-999",
+		},
+		{
+			name: "falls back to config",
+			setup: func(t *testing.T, procRoot, configPath string) {
+				writeProcEntry(t, procRoot, "1", map[string]string{"comm": "systemd\n"})
+				if err := os.WriteFile(configPath, []byte("version = 2\noom_score = -500\n"), 0644); err != nil {
+					t.Fatalf("failed to write config: %v", err)
+				}
+			},
+			want: "-500",
+		},
+		{
+			name: "config with trailing comment",
+			setup: func(t *testing.T, procRoot, configPath string) {
+				writeProcEntry(t, procRoot, "1", map[string]string{"comm": "systemd\n"})
+				if err := os.WriteFile(configPath, []byte("oom_score = -999 # keep\n"), 0644); err != nil {
+					t.Fatalf("failed to write config: %v", err)
+				}
+			},
+			want: "-999",
+		},
+		{
+			name: "oom_score inside a table ignored",
+			setup: func(t *testing.T, procRoot, configPath string) {
+				writeProcEntry(t, procRoot, "1", map[string]string{"comm": "systemd\n"})
+				config := "version = 2\n[plugins.\"io.containerd.runtime.v1.linux\"]\n  oom_score = -500\n"
+				if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+					t.Fatalf("failed to write config: %v", err)
+				}
+			},
+			want: "",
+		},
+		{
+			name: "socket owner among several daemons",
+			setup: func(t *testing.T, procRoot, _ string) {
+				writeProcEntry(t, procRoot, "700", map[string]string{"comm": "containerd\n", "oom_score_adj": "0\n"})
+				writeProcEntry(t, procRoot, "812", map[string]string{"comm": "containerd\n", "oom_score_adj": "-999\n"})
+				writeProcSocket(t, procRoot, "700", "4001", "/run/user/1000/containerd/containerd.sock")
+				writeProcSocket(t, procRoot, "812", "4002", "/run/containerd/containerd.sock")
+			},
+			want: "-999",
+		},
+		{
+			name: "several daemons without socket owner falls back to config",
+			setup: func(t *testing.T, procRoot, configPath string) {
+				writeProcEntry(t, procRoot, "700", map[string]string{"comm": "containerd\n", "oom_score_adj": "0\n"})
+				writeProcEntry(t, procRoot, "812", map[string]string{"comm": "containerd\n", "oom_score_adj": "-999\n"})
+				if err := os.WriteFile(configPath, []byte("oom_score = -500\n"), 0644); err != nil {
+					t.Fatalf("failed to write config: %v", err)
+				}
+			},
+			want: "-500",
+		},
+		{
+			name: "nothing discoverable",
+			setup: func(t *testing.T, procRoot, _ string) {
+				writeProcEntry(t, procRoot, "self", map[string]string{"comm": "containerd\n"})
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			procRoot := filepath.Join(dir, "proc")
+			configPath := filepath.Join(dir, "config.toml")
+			tt.setup(t, procRoot, configPath)
+
+			if got := daemonOOMScoreAdj(procRoot, Containerd, "/run/containerd/containerd.sock", configPath); got != tt.want {
+				t.Errorf("daemonOOMScoreAdj() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
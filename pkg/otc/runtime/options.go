@@ -0,0 +1,234 @@
+package runtime
+
+import (
+	"log/slog"
+	"regexp"
+	"time"
+)
+
+// DetectorOption configures a Detector.
+type DetectorOption func(*Detector)
+
+// timeoutSetter is implemented by the built-in CRI detectors so WithCRITimeout can reach them.
+type timeoutSetter interface {
+	setTimeout(timeout time.Duration)
+}
+
+// retrySetter is implemented by the built-in CRI detectors so WithCRIRetry can reach them.
+type retrySetter interface {
+	setRetry(attempts int, backoff time.Duration)
+}
+
+// namespaceSetter is implemented by the built-in containerd detector so
+// WithCRINamespace can reach it.
+type namespaceSetter interface {
+	setNamespace(namespace string)
+}
+
+// socketPathAppender is implemented by the built-in CRI detectors so
+// WithExtraSocketPaths can reach them.
+type socketPathAppender interface {
+	appendSocketPaths(paths []string)
+}
+
+// loggerSetter is implemented by the built-in detectors so WithLogger can reach them.
+type loggerSetter interface {
+	setLogger(logger *slog.Logger)
+}
+
+// WithStrictSelection makes Detect fail with ErrAmbiguousSelection when several
+// runtimes tie for the highest priority, instead of selecting the first one found.
+// Use it to force explicit configuration (e.g. OTC_RUNTIME) in ambiguous environments.
+func WithStrictSelection() DetectorOption {
+	return func(d *Detector) {
+		d.strict = true
+	}
+}
+
+// WithNameFilter keeps only runtimes whose name matches re, before selection.
+// Excluded runtimes are reported in a low-severity warning.
+// The pattern is compiled by the caller (regexp.Compile or regexp.MustCompile),
+// so an invalid pattern is rejected before the detector is constructed.
+// A nil re disables filtering.
+func WithNameFilter(re *regexp.Regexp) DetectorOption {
+	return func(d *Detector) {
+		d.nameFilter = re
+	}
+}
+
+// WithPriorityOverride replaces the Priority of detected runtimes by name
+// before they are sorted and one is selected, e.g.
+// map[string]int{"podman": PriorityCRI + 1} to prefer Podman over everything.
+// Overrides for runtimes that aren't detected are ignored. The map is copied.
+func WithPriorityOverride(priorities map[string]int) DetectorOption {
+	return func(d *Detector) {
+		d.priorities = make(map[string]int, len(priorities))
+		for name, p := range priorities {
+			d.priorities[name] = p
+		}
+	}
+}
+
+// WithMinVersion drops detected runtimes named name whose version is older
+// than minVersion (compared as by Runtime.AtLeast), before selection, so e.g.
+// WithMinVersion("crun", "1.8") never selects an older crun on PATH. Runtimes
+// whose version can't be parsed are dropped too. Excluded runtimes are reported
+// in a low-severity warning. It applies under OTC_RUNTIME too, where an
+// overridden runtime that is too old is reported as not found.
+// Repeat the option for several runtimes; a later minimum for the same name
+// replaces an earlier one.
+func WithMinVersion(name, minVersion string) DetectorOption {
+	return func(d *Detector) {
+		if d.minVersions == nil {
+			d.minVersions = make(map[string]string)
+		}
+		d.minVersions[name] = minVersion
+	}
+}
+
+// WithResultHook registers a hook that may inspect or modify the Result just
+// before Detect returns, e.g. to annotate capabilities, add custom runtimes, or
+// drop unwanted ones. Hooks run in registration order for both automatic and
+// OTC_RUNTIME detection, and in DetectWithStrategy after the strategy selects.
+// An error from a hook fails the call.
+//
+// Hooks that change Runtimes are responsible for keeping Selected pointing at
+// the intended runtime.
+func WithResultHook(hook func(*Result) error) DetectorOption {
+	return func(d *Detector) {
+		d.hooks = append(d.hooks, hook)
+	}
+}
+
+// WithEmptyResults makes Detect report "nothing found" uniformly as a non-nil
+// Result with IsEmpty() true, carrying the detector errors in Warnings.
+// By default Detect instead returns the first detector error when no runtime
+// is found, or an error when the OTC_RUNTIME runtime is missing.
+// Errors are then reserved for fatal failures: a closed detector, an invalid
+// or unconfigured OTC_RUNTIME, strict-selection ties, and failing result hooks.
+func WithEmptyResults() DetectorOption {
+	return func(d *Detector) {
+		d.emptyResults = true
+	}
+}
+
+// WithDockerDetector adds a Docker detector, run after the Podman detector by
+// default. Docker is also required for OTC_RUNTIME=docker.
+func WithDockerDetector(docker DockerDetector) DetectorOption {
+	return func(d *Detector) {
+		d.docker = docker
+	}
+}
+
+// WithDetectorOrder sets the order in which the OCI, CRI, Podman, and Docker detectors run,
+// e.g. []Type{TypeCRI, TypeOCI} to probe sockets before PATH on Kubernetes nodes.
+// Types left out run afterwards in the default order (OCI, CRI, Podman, Docker); types
+// without a detector are ignored. The order decides warning order and breaks
+// ties between runtimes of equal priority; it does not change priorities.
+func WithDetectorOrder(order []Type) DetectorOption {
+	return func(d *Detector) {
+		d.order = append([]Type(nil), order...)
+	}
+}
+
+// WithOnRuntimeFound registers a callback invoked synchronously each time a
+// detector confirms a runtime, before sorting, filtering, and selection, so
+// progress UIs can update and callers can react early. Detection waits for the
+// callback to return, so it should be quick.
+//
+// Calls are serialized, even if detectors run concurrently, so fn need not be
+// safe for concurrent use. A runtime reported to fn may still be dropped from
+// the Result later, e.g. by WithNameFilter, the OTC_RUNTIME list, or a result hook.
+func WithOnRuntimeFound(fn func(Runtime)) DetectorOption {
+	return func(d *Detector) {
+		d.onFound = fn
+	}
+}
+
+// WithCRITimeout sets the timeout applied to each CRI call made by the CRI
+// detector, overriding both the default and OTC_DETECT_TIMEOUT. It has no
+// effect on CRI detectors other than the built-in containerd and CRI-O ones.
+func WithCRITimeout(timeout time.Duration) DetectorOption {
+	return func(d *Detector) {
+		if s, ok := d.cri.(timeoutSetter); ok {
+			s.setTimeout(timeout)
+		}
+	}
+}
+
+// WithCRIRetry retries the CRI Version call up to attempts times in all when
+// it fails because the runtime is not accepting connections yet, e.g. a unit
+// started before containerd finished booting. The wait between attempts starts
+// at backoff and doubles each time; retrying stops early at the context deadline.
+// The default is a single attempt. It has no effect on CRI detectors other
+// than the built-in containerd and CRI-O ones.
+func WithCRIRetry(attempts int, backoff time.Duration) DetectorOption {
+	return func(d *Detector) {
+		if s, ok := d.cri.(retrySetter); ok {
+			s.setRetry(attempts, backoff)
+		}
+	}
+}
+
+// WithCRINamespace sets the containerd namespace sent with CRI calls, like the
+// containerd detector's WithNamespace; the default is "k8s.io". It has no
+// effect on CRI detectors other than the built-in containerd one, since the
+// namespace is a containerd concept.
+func WithCRINamespace(namespace string) DetectorOption {
+	return func(d *Detector) {
+		if s, ok := d.cri.(namespaceSetter); ok {
+			s.setNamespace(namespace)
+		}
+	}
+}
+
+// WithExtraSocketPaths adds socket paths for the CRI detector to search after
+// its standard locations, e.g. for daemons started with a nonstandard --address.
+// It has no effect on CRI detectors other than the built-in containerd and
+// CRI-O ones, or on a containerd detector configured with WithEndpoint.
+func WithExtraSocketPaths(paths ...string) DetectorOption {
+	return func(d *Detector) {
+		if a, ok := d.cri.(socketPathAppender); ok {
+			a.appendSocketPaths(paths)
+		}
+	}
+}
+
+// WithLogger logs detection progress at debug level to logger: each detector
+// run and its outcome, each socket path tried and why it was skipped, and each
+// version parse. The built-in detectors log their own steps; other detectors
+// only get the start and outcome of their runs. Without it nothing is logged.
+func WithLogger(logger *slog.Logger) DetectorOption {
+	return func(d *Detector) {
+		d.logger = logger
+	}
+}
+
+// WithMetrics reports detection metrics to hook: the latency and outcome of
+// each detector run, and each runtime found. Runs made by Detect, DetectAll,
+// DetectFirst, and OTC_RUNTIME detection are all reported.
+func WithMetrics(hook MetricsHook) DetectorOption {
+	return func(d *Detector) {
+		d.metrics = hook
+	}
+}
+
+// WithoutHostChecks skips the checks that inspect the host rather than the
+// detected runtimes: systemd unit state and sandboxing, no_new_privs, AppArmor,
+// OCI binary version skew, and the kubelet config. KubernetesNode is then
+// always false. Use it when the detectors report simulated runtimes.
+func WithoutHostChecks() DetectorOption {
+	return func(d *Detector) {
+		d.noHostChecks = true
+	}
+}
+
+// WithOverride forces detection of the named runtime, as if OTC_RUNTIME were
+// set to name, and takes precedence over the environment variable.
+// An empty name selects automatic detection even when OTC_RUNTIME is set.
+// The name is validated by Detect, like the environment variable.
+func WithOverride(name string) DetectorOption {
+	return func(d *Detector) {
+		d.override = name
+	}
+}
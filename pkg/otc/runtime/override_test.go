@@ -2,7 +2,10 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -132,13 +135,13 @@ func TestDetector_Detect_WithOverride(t *testing.T) {
 			errMsg:   "Podman detector not configured",
 		},
 		{
-			name:     "override docker - not supported",
+			name:     "override docker - detector not configured",
 			override: "docker",
 			oci:      NewOCIDetector(),
 			cri:      nil,
-			podman:   nil,
+			podman:   nil, // Docker detector not provided
 			wantErr:  true,
-			errMsg:   "docker runtime not yet supported",
+			errMsg:   "Docker detector not configured",
 		},
 	}
 
@@ -177,6 +180,190 @@ func TestDetector_Detect_WithOverride(t *testing.T) {
 	}
 }
 
+func TestDetector_Detect_OverrideList(t *testing.T) {
+	t.Parallel()
+
+	runc := Runtime{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}
+	crun := Runtime{Name: Crun, Type: TypeOCI, Priority: PriorityOCI}
+	containerd := Runtime{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}
+
+	tests := []struct {
+		name         string
+		override     string
+		oci          []Runtime
+		cri          *countingCRIDetector
+		want         []string
+		wantErr      error
+		wantWarnings int
+	}{
+		{
+			name:     "first listed found",
+			override: "crun,runc",
+			oci:      []Runtime{runc, crun},
+			want:     []string{Crun, Runc},
+		},
+		{
+			name:     "falls back to later entry",
+			override: " crun , runc ",
+			oci:      []Runtime{runc},
+			want:     []string{Runc},
+		},
+		{
+			name:     "list order beats priority",
+			override: "runc,containerd",
+			oci:      []Runtime{runc},
+			cri:      &countingCRIDetector{runtimes: []Runtime{containerd}},
+			want:     []string{Runc, Containerd},
+		},
+		{
+			name:         "failed detector becomes warning",
+			override:     "containerd,runc",
+			oci:          []Runtime{runc},
+			cri:          &countingCRIDetector{err: errors.New("socket not found")},
+			want:         []string{Runc},
+			wantWarnings: 1,
+		},
+		{
+			name:         "unconfigured detector becomes warning",
+			override:     "crun,containerd",
+			oci:          []Runtime{crun},
+			want:         []string{Crun},
+			wantWarnings: 1,
+		},
+		{
+			name:     "none found with unconfigured detector",
+			override: "youki,containerd",
+			oci:      []Runtime{runc},
+			wantErr:  ErrDetectorNotConfigured,
+		},
+		{
+			name:     "none found",
+			override: "youki,crun",
+			oci:      []Runtime{runc},
+			wantErr:  ErrRuntimeNotFound,
+		},
+		{
+			name:     "invalid entry",
+			override: "crun,rkt",
+			oci:      []Runtime{crun},
+			wantErr:  ErrInvalidOverride,
+		},
+		{
+			name:     "only separators",
+			override: ",",
+			oci:      []Runtime{crun},
+			wantErr:  ErrInvalidOverride,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			oci := &countingCRIDetector{runtimes: tt.oci}
+			var cri CRIDetector
+			if tt.cri != nil {
+				cri = tt.cri
+			}
+			detector := NewDetector(oci, cri, nil, WithOverride(tt.override), WithoutHostChecks())
+
+			result, err := detector.Detect(context.Background())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Detect() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			var got []string
+			for _, rt := range result.Runtimes {
+				got = append(got, rt.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Runtimes = %v, want %v", got, tt.want)
+			}
+			if result.Selected.Name != tt.want[0] {
+				t.Errorf("Selected = %s, want %s", result.Selected.Name, tt.want[0])
+			}
+			if result.Mode != ModeOverride {
+				t.Errorf("Mode = %v, want %v", result.Mode, ModeOverride)
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %v, want %d", result.Warnings, tt.wantWarnings)
+			}
+			if calls := oci.calls.Load(); calls != 1 {
+				t.Errorf("OCI detector called %d times, want 1", calls)
+			}
+		})
+	}
+}
+
+func TestDetector_Detect_OverrideRunsChecks(t *testing.T) {
+	t.Parallel()
+
+	spec := func(name string) Runtime {
+		return Runtime{Name: name, Type: TypeOCI, Priority: PriorityOCI, Capabilities: map[string]string{
+			"ociVersionMin": "1.0.0",
+			"ociVersionMax": "1.1.0",
+		}}
+	}
+	oci := &fakeOCIDetector{runtimes: []Runtime{spec(Runc), spec(Crun)}}
+	cri := &fakeCRIDetector{runtimes: []Runtime{
+		{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI, Path: "/usr/bin/containerd"},
+		{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI, Path: "unix:///run/containerd/containerd.sock"},
+	}}
+	detector := NewDetector(oci, cri, nil, WithOverride("runc,crun,containerd"), WithoutHostChecks())
+
+	result, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	// Duplicates collapse as in automatic detection
+	if result.RuntimeCount() != 3 {
+		t.Fatalf("Runtimes = %v, want runc, crun, containerd once each", result.Runtimes)
+	}
+	if got := result.Runtimes[2].Path; got != "unix:///run/containerd/containerd.sock" {
+		t.Errorf("containerd Path = %q, want the socket", got)
+	}
+
+	// Cross-runtime annotations apply to the listed runtimes
+	if got := result.Runtimes[0].Capabilities["interchangeableWith"]; got != Crun {
+		t.Errorf("runc interchangeableWith = %q, want %q", got, Crun)
+	}
+}
+
+func TestParseOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: "", want: nil},
+		{value: "runc", want: []string{Runc}},
+		{value: "crun,runc", want: []string{Crun, Runc}},
+		{value: " crun , runc ,", want: []string{Crun, Runc}},
+		{value: "runc,,runc", want: []string{Runc}},
+		{value: ",", want: nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			if got := parseOverride(tt.value); !slices.Equal(got, tt.want) {
+				t.Errorf("parseOverride(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDetector_Detect_WithoutOverride(t *testing.T) {
 	t.Parallel()
 
@@ -215,12 +402,91 @@ func TestNewDetector_ReadsEnv(t *testing.T) {
 		}
 	}()
 
+	if err := os.Setenv("OTC_RUNTIME_PATH", " /opt/runc/bin/runc "); err != nil {
+		t.Fatalf("failed to set OTC_RUNTIME_PATH: %v", err)
+	}
+	defer func() {
+		if err := os.Unsetenv("OTC_RUNTIME_PATH"); err != nil {
+			t.Errorf("failed to cleanup OTC_RUNTIME_PATH: %v", err)
+		}
+	}()
+
 	detector := NewDetector(NewOCIDetector(), nil, nil)
 
 	// Check that detector has override set
 	if detector.override != Runc {
 		t.Errorf("expected override to be 'runc', got %q", detector.override)
 	}
+	if detector.overridePath != "/opt/runc/bin/runc" {
+		t.Errorf("expected overridePath to be '/opt/runc/bin/runc', got %q", detector.overridePath)
+	}
+}
+
+func TestDetector_Detect_OverridePath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	runcPath := writeFakeBinary(t, dir, "runc-custom", `echo "runc version 1.2.3"`)
+	plainPath := filepath.Join(dir, "runc-plain")
+	if err := os.WriteFile(plainPath, []byte("#!/bin/sh\n"), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", plainPath, err)
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		wantVersion string
+		wantErr     error
+	}{
+		{
+			name:        "executable binary",
+			path:        runcPath,
+			wantVersion: "1.2.3",
+		},
+		{
+			name:    "not executable",
+			path:    plainPath,
+			wantErr: ErrInvalidOverride,
+		},
+		{
+			name:    "missing",
+			path:    filepath.Join(dir, "missing"),
+			wantErr: os.ErrNotExist,
+		},
+		{
+			name:    "directory",
+			path:    dir,
+			wantErr: ErrInvalidOverride,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// No search name matches, so a found runtime came from overridePath
+			detector := NewDetector(NewOCIDetectorWithNames("no-such-runtime"), nil, nil, WithOverride(Runc))
+			detector.overridePath = tt.path
+
+			result, err := detector.Detect(context.Background())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Detect() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if result.Selected.Path != tt.path {
+				t.Errorf("Selected.Path = %q, want %q", result.Selected.Path, tt.path)
+			}
+			if result.Selected.Version != tt.wantVersion {
+				t.Errorf("Selected.Version = %q, want %q", result.Selected.Version, tt.wantVersion)
+			}
+		})
+	}
 }
 
 // contains checks if string s contains substring substr.
@@ -239,3 +505,161 @@ func indexString(s, substr string) int {
 	}
 	return -1
 }
+
+func TestValidateOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+		errMsg  string
+	}{
+		{name: "empty", value: "", wantErr: false},
+		{name: "whitespace only", value: "   ", wantErr: false},
+		{name: "oci runtime", value: Crun, wantErr: false},
+		{name: "cri runtime", value: CRIO, wantErr: false},
+		{name: "podman", value: Podman, wantErr: false},
+		{name: "surrounding whitespace", value: " containerd ", wantErr: false},
+		{name: "unknown runtime", value: "rkt", wantErr: true, errMsg: "invalid OTC_RUNTIME value"},
+		{name: "wrong case", value: "Runc", wantErr: true, errMsg: "invalid OTC_RUNTIME value"},
+		{name: "typo", value: "contaierd", wantErr: true, errMsg: "did you mean 'containerd'?"},
+		{name: "docker", value: Docker, wantErr: false},
+		{name: "list", value: "crun, runc", wantErr: false},
+		{name: "list with invalid entry", value: "crun,rkt", wantErr: true, errMsg: "rkt"},
+		{name: "only separators", value: " , ", wantErr: true, errMsg: "invalid OTC_RUNTIME value"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateOverride(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateOverride(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr && !contains(err.Error(), tt.errMsg) {
+				t.Errorf("error message %q does not contain %q", err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestValidateRuntimeName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range ValidRuntimeNames() {
+		if err := ValidateRuntimeName(name); err != nil {
+			t.Errorf("ValidateRuntimeName(%q) error = %v", name, err)
+		}
+	}
+
+	for _, name := range []string{"", " runc", "docekr"} {
+		err := ValidateRuntimeName(name)
+		if !errors.Is(err, ErrInvalidOverride) {
+			t.Errorf("ValidateRuntimeName(%q) error = %v, want %v", name, err, ErrInvalidOverride)
+			continue
+		}
+		if !contains(err.Error(), "valid: runc, crun, youki, runsc, containerd, crio, podman, docker") {
+			t.Errorf("error message %q does not list valid names", err.Error())
+		}
+	}
+}
+
+func TestValidRuntimeNames_ReturnsCopy(t *testing.T) {
+	t.Parallel()
+
+	names := ValidRuntimeNames()
+	names[0] = "mutated"
+	if got := ValidRuntimeNames()[0]; got != Runc {
+		t.Errorf("ValidRuntimeNames()[0] = %q after mutating a copy, want %q", got, Runc)
+	}
+}
+
+func TestNewValidatedDetector(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		override string
+		wantErr  bool
+	}{
+		{name: "no override", override: ""},
+		{name: "valid override", override: Docker},
+		{name: "typo", override: "docekr", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector, err := NewValidatedDetector(nil, nil, nil, WithOverride(tt.override))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidOverride) {
+					t.Fatalf("NewValidatedDetector() error = %v, want %v", err, ErrInvalidOverride)
+				}
+				if detector != nil {
+					t.Error("NewValidatedDetector() returned a detector with an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewValidatedDetector() error = %v", err)
+			}
+			if detector == nil {
+				t.Fatal("NewValidatedDetector() returned nil detector")
+			}
+		})
+	}
+}
+
+func TestDetector_Detect_Mode(t *testing.T) {
+	t.Parallel()
+
+	oci := &fakeOCIDetector{runtimes: []Runtime{
+		{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+		{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+	}}
+
+	tests := []struct {
+		name           string
+		override       string
+		wantMode       Mode
+		wantOverridden bool
+	}{
+		{
+			name:           "auto detection",
+			override:       "",
+			wantMode:       ModeAuto,
+			wantOverridden: false,
+		},
+		{
+			name:           "pinned via override",
+			override:       Crun,
+			wantMode:       ModeOverride,
+			wantOverridden: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector := &Detector{oci: oci, override: tt.override}
+
+			result, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if result.Mode != tt.wantMode {
+				t.Errorf("Mode = %q, want %q", result.Mode, tt.wantMode)
+			}
+			if got := result.WasOverridden(); got != tt.wantOverridden {
+				t.Errorf("WasOverridden() = %v, want %v", got, tt.wantOverridden)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package runtime
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// VersionParser extracts the version and optional capabilities from a
+// runtime's `--version` output. An empty version means parsing failed.
+type VersionParser func(output string) (version string, caps map[string]string)
+
+var (
+	versionParsersMu sync.RWMutex
+
+	// versionParsers holds per-runtime parsers keyed by runtime name.
+	// Runtimes without an entry use the generic parseVersion heuristic.
+	versionParsers = map[string]VersionParser{
+		Youki:    parseYoukiVersion,
+		Runsc:    parseRunscVersion,
+		WasmEdge: parseWasmEdgeVersion,
+	}
+)
+
+// RegisterVersionParser installs a version parser for the named runtime,
+// replacing any existing one. It is safe for concurrent use.
+func RegisterVersionParser(name string, parser VersionParser) {
+	versionParsersMu.Lock()
+	defer versionParsersMu.Unlock()
+
+	versionParsers[name] = parser
+}
+
+// versionParserFor returns the parser registered for name, or the generic fallback.
+func versionParserFor(name string) VersionParser {
+	versionParsersMu.RLock()
+	defer versionParsersMu.RUnlock()
+
+	if parser, ok := versionParsers[name]; ok {
+		return parser
+	}
+	return parseGenericVersion
+}
+
+// parseGenericVersion adapts parseVersion to the VersionParser signature.
+func parseGenericVersion(output string) (string, map[string]string) {
+	return parseVersion(output), nil
+}
+
+// parseYoukiVersion parses youki's multi-line banner, which additionally reports
+// its Rust toolchain and libseccomp versions. Each detail line is optional.
+func parseYoukiVersion(output string) (string, map[string]string) {
+	fields := bannerFields(output)
+	caps := make(map[string]string)
+	for key, capability := range map[string]string{
+		"rust version": "rustVersion",
+		"libseccomp":   "libseccomp",
+	} {
+		if value := fields[key]; value != "" {
+			caps[capability] = value
+		}
+	}
+	return parseVersion(output), caps
+}
+
+// parseRunscVersion parses gVisor's banner, whose version is a dated release
+// tag such as "release-20240101.0". The release date is reported as the
+// releaseDate capability (YYYY-MM-DD) since gVisor has no semantic version.
+func parseRunscVersion(output string) (string, map[string]string) {
+	version := parseVersion(output)
+
+	tag, ok := strings.CutPrefix(version, "release-")
+	if !ok {
+		return version, nil
+	}
+	date, _, _ := strings.Cut(tag, ".")
+	released, err := time.Parse("20060102", date)
+	if err != nil {
+		return version, nil
+	}
+	return version, map[string]string{"releaseDate": released.Format(time.DateOnly)}
+}
@@ -0,0 +1,137 @@
+package runtime
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYoukiVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		output      string
+		wantVersion string
+		wantCaps    map[string]string
+	}{
+		{
+			name: "full banner",
+			output: `youki version 0.3.3
+commit: 0.3.3-0-4f3c830
+libcgroups version 0.3.3
+libcontainer version 0.3.3
+libseccomp: 2.5.4
+rust version: 1.74.0
+`,
+			wantVersion: "0.3.3",
+			wantCaps: map[string]string{
+				"rustVersion": "1.74.0",
+				"libseccomp":  "2.5.4",
+			},
+		},
+		{
+			name:        "short banner",
+			output:      "youki version 0.3.3\ncommit: 4f3c8307",
+			wantVersion: "0.3.3",
+			wantCaps:    map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			version, caps := parseYoukiVersion(tt.output)
+			if version != tt.wantVersion {
+				t.Errorf("parseYoukiVersion() version = %q, want %q", version, tt.wantVersion)
+			}
+			if !reflect.DeepEqual(caps, tt.wantCaps) {
+				t.Errorf("parseYoukiVersion() caps = %v, want %v", caps, tt.wantCaps)
+			}
+		})
+	}
+}
+
+func TestParseRunscVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		output      string
+		wantVersion string
+		wantCaps    map[string]string
+	}{
+		{
+			name:        "release banner",
+			output:      "runsc version release-20240101.0\nspec: 1.1.0\n",
+			wantVersion: "release-20240101.0",
+			wantCaps:    map[string]string{"releaseDate": "2024-01-01"},
+		},
+		{
+			name:        "development build",
+			output:      "runsc version 0.0.0\nspec: 1.1.0\n",
+			wantVersion: "0.0.0",
+			wantCaps:    nil,
+		},
+		{
+			name:        "malformed release date",
+			output:      "runsc version release-2024.0\n",
+			wantVersion: "release-2024.0",
+			wantCaps:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			version, caps := parseRunscVersion(tt.output)
+			if version != tt.wantVersion {
+				t.Errorf("parseRunscVersion() version = %q, want %q", version, tt.wantVersion)
+			}
+			if !reflect.DeepEqual(caps, tt.wantCaps) {
+				t.Errorf("parseRunscVersion() caps = %v, want %v", caps, tt.wantCaps)
+			}
+		})
+	}
+}
+
+func TestRegisterVersionParser(t *testing.T) {
+	t.Parallel()
+
+	const name = "kata-runtime-test"
+
+	if got, _ := versionParserFor(name)("kata-runtime-test version 3.2.0"); got != "3.2.0" {
+		t.Errorf("fallback parser version = %q, want %q", got, "3.2.0")
+	}
+
+	// Kata prints "kata-runtime  : 3.2.0" on its first line
+	RegisterVersionParser(name, func(output string) (string, map[string]string) {
+		first, _, _ := strings.Cut(output, "\n")
+		_, version, _ := strings.Cut(first, ":")
+		return strings.TrimSpace(version), map[string]string{"parser": "custom"}
+	})
+
+	binDir := t.TempDir()
+	writeFakeBinary(t, binDir, name, `echo "kata-runtime  : 3.2.0"; echo "   commit   : abc123"`)
+
+	detector := &ociDetector{}
+	info, err := detector.extractVersion(context.Background(), name, filepath.Join(binDir, name))
+	if err != nil {
+		t.Fatalf("extractVersion() error = %v", err)
+	}
+	if info.version != "3.2.0" {
+		t.Errorf("version = %q, want %q", info.version, "3.2.0")
+	}
+	if info.caps["parser"] != "custom" {
+		t.Errorf("custom parser not used, caps = %v", info.caps)
+	}
+	if info.commit != "abc123" {
+		t.Errorf("commit = %q, want %q", info.commit, "abc123")
+	}
+}
@@ -0,0 +1,117 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// defaultPodmanSocket is the rootful Podman API socket
+const defaultPodmanSocket = "/run/podman/podman.sock"
+
+// podmanVersionURL is the libpod version endpoint; the host is ignored on a Unix socket
+const podmanVersionURL = "http://d/libpod/version"
+
+// podmanDetector detects Podman via its API sockets, falling back to the CLI
+type podmanDetector struct {
+	rootfulSocket string
+	timeout       time.Duration
+	logger        *slog.Logger // Debug tracing; nil logs nothing
+
+	// timeoutWarning reports an invalid OTC_DETECT_TIMEOUT with each detection
+	timeoutWarning error
+}
+
+// NewPodmanDetector creates a Podman detector that checks the rootful socket
+// (/run/podman/podman.sock), then the rootless one under $XDG_RUNTIME_DIR,
+// and falls back to `podman --version` when neither answers.
+// The socket timeout is read from OTC_DETECT_TIMEOUT when set; an invalid
+// value falls back to the default and is reported as a warning by Detect.
+func NewPodmanDetector() PodmanDetector {
+	timeout, timeoutErr := getTimeoutFromEnv(defaultDetectTimeout)
+	return &podmanDetector{
+		rootfulSocket:  defaultPodmanSocket,
+		timeout:        timeout,
+		timeoutWarning: timeoutErr,
+	}
+}
+
+// setLogger sets the logger used to trace socket queries.
+func (d *podmanDetector) setLogger(logger *slog.Logger) {
+	d.logger = logger
+}
+
+// Detect finds Podman, preferring a live API socket over the CLI.
+func (d *podmanDetector) Detect(ctx context.Context) ([]Runtime, error) {
+	var errs []error
+	for _, socket := range d.socketPaths() {
+		version, err := d.socketVersion(ctx, socket)
+		loggerOrDiscard(d.logger).Debug("API version queried", "runtime", Podman, "path", socket, "version", version, "error", err)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return []Runtime{{
+			Name:     Podman,
+			Type:     TypePodman,
+			Version:  version,
+			Path:     socket,
+			Priority: PriorityPodman,
+			Rootless: isRootlessSocket(socket),
+		}}, d.timeoutWarning
+	}
+
+	// No usable socket; the CLI still proves Podman is installed
+	path, err := exec.LookPath(Podman)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("podman not found in PATH: %w", err))
+		return nil, fmt.Errorf("podman %w: %w", ErrRuntimeNotFound, errors.Join(errs...))
+	}
+
+	info, err := (&ociDetector{}).extractVersion(ctx, Podman, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version for podman: %w", err)
+	}
+
+	return []Runtime{{
+		Name:     Podman,
+		Type:     TypePodman,
+		Version:  info.version,
+		Path:     path,
+		Priority: PriorityPodman,
+		Commit:   info.commit,
+		Rootless: os.Geteuid() != 0, // The CLI runs rootless for any non-root user
+	}}, d.timeoutWarning
+}
+
+// socketPaths returns the rootful socket, then the rootless one if
+// XDG_RUNTIME_DIR is set.
+func (d *podmanDetector) socketPaths() []string {
+	paths := []string{d.rootfulSocket}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "podman", "podman.sock"))
+	}
+	return paths
+}
+
+// socketVersion queries the libpod version endpoint on socket.
+func (d *podmanDetector) socketVersion(ctx context.Context, socket string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	var body struct {
+		Version string `json:"Version"`
+	}
+	if err := getSocketJSON(ctx, Podman, socket, podmanVersionURL, &body); err != nil {
+		return "", err
+	}
+	if body.Version == "" {
+		return "", fmt.Errorf("podman version response on %s has no version", socket)
+	}
+	return body.Version, nil
+}
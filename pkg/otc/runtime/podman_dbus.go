@@ -0,0 +1,188 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// podmanSocketUnit is the systemd socket unit that activates the Podman API service.
+const podmanSocketUnit = "podman.socket"
+
+// socketListen is one entry of a systemd socket unit's Listen property,
+// e.g. {Type: "Stream", Address: "/run/user/1000/podman/podman.sock"}.
+type socketListen struct {
+	Type    string
+	Address string
+}
+
+// systemdBus queries systemd unit state over a D-Bus connection.
+type systemdBus interface {
+	// socketUnit returns the ActiveState and Listen addresses of a socket unit.
+	socketUnit(ctx context.Context, unit string) (activeState string, listen []socketListen, err error)
+	Close() error
+}
+
+// busConnector opens a connection to one D-Bus bus.
+type busConnector struct {
+	rootless bool // Session bus: user services; system bus: rootful services
+	connect  func() (systemdBus, error)
+}
+
+// PodmanDBusDetector finds Podman's systemd-managed API socket by asking systemd
+// over D-Bus, first on the user session bus (rootless) and then on the system bus.
+// When D-Bus is unavailable or no bus knows podman.socket, it defers to a fallback
+// detector, typically one that probes socket paths directly.
+type PodmanDBusDetector struct {
+	buses    []busConnector
+	fallback PodmanDetector
+}
+
+// NewPodmanDBusDetector creates a D-Bus based Podman detector.
+// fallback may be nil, in which case failing to find Podman over D-Bus is an error.
+func NewPodmanDBusDetector(fallback PodmanDetector) *PodmanDBusDetector {
+	return &PodmanDBusDetector{
+		buses: []busConnector{
+			{rootless: true, connect: connectSessionBus},
+			{rootless: false, connect: connectSystemBus},
+		},
+		fallback: fallback,
+	}
+}
+
+// Detect queries each bus for an active podman.socket unit.
+// The version comes from `podman --version` when the CLI is in PATH.
+func (d *PodmanDBusDetector) Detect(ctx context.Context) ([]Runtime, error) {
+	var errs []error
+	for _, bus := range d.buses {
+		runtime, err := d.detectOnBus(ctx, bus)
+		if err == nil {
+			return []Runtime{runtime}, nil
+		}
+		errs = append(errs, err)
+	}
+
+	if d.fallback != nil {
+		return d.fallback.Detect(ctx)
+	}
+	return nil, fmt.Errorf("podman %w via D-Bus: %w", ErrRuntimeNotFound, errors.Join(errs...))
+}
+
+// detectOnBus looks up podman.socket on one bus.
+func (d *PodmanDBusDetector) detectOnBus(ctx context.Context, bus busConnector) (Runtime, error) {
+	conn, err := bus.connect()
+	if err != nil {
+		return Runtime{}, fmt.Errorf("failed to connect to D-Bus: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	state, listen, err := conn.socketUnit(ctx, podmanSocketUnit)
+	if err != nil {
+		return Runtime{}, err
+	}
+
+	socket := ""
+	for _, l := range listen {
+		if l.Type == "Stream" {
+			socket = l.Address
+			break
+		}
+	}
+	if socket == "" {
+		return Runtime{}, fmt.Errorf("%s has no stream socket", podmanSocketUnit)
+	}
+
+	return Runtime{
+		Name:     Podman,
+		Type:     TypePodman,
+		Version:  podmanCLIVersion(ctx),
+		Path:     socket,
+		Priority: PriorityPodman,
+		Rootless: bus.rootless,
+		Capabilities: map[string]string{
+			"serviceState": state,
+		},
+	}, nil
+}
+
+// podmanCLIVersion returns the version of the podman CLI in PATH, or "" if unavailable.
+func podmanCLIVersion(ctx context.Context) string {
+	path, err := exec.LookPath(Podman)
+	if err != nil {
+		return ""
+	}
+	info, err := (&ociDetector{}).extractVersion(ctx, Podman, path)
+	if err != nil {
+		return ""
+	}
+	return info.version
+}
+
+// dbusSystemdBus implements systemdBus with a godbus connection.
+type dbusSystemdBus struct {
+	conn *dbus.Conn
+}
+
+// connectSessionBus connects to the user's session bus.
+func connectSessionBus() (systemdBus, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, err
+	}
+	return &dbusSystemdBus{conn: conn}, nil
+}
+
+// connectSystemBus connects to the system bus.
+func connectSystemBus() (systemdBus, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, err
+	}
+	return &dbusSystemdBus{conn: conn}, nil
+}
+
+// socketUnit resolves the unit object and reads its state and listen addresses.
+func (b *dbusSystemdBus) socketUnit(ctx context.Context, unit string) (string, []socketListen, error) {
+	manager := b.conn.Object("org.freedesktop.systemd1", "/org/freedesktop/systemd1")
+
+	var path dbus.ObjectPath
+	if err := manager.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.GetUnit", 0, unit).Store(&path); err != nil {
+		return "", nil, fmt.Errorf("systemd unit %s not loaded: %w", unit, err)
+	}
+	obj := b.conn.Object("org.freedesktop.systemd1", path)
+
+	var state string
+	if err := getProperty(ctx, obj, "org.freedesktop.systemd1.Unit", "ActiveState", &state); err != nil {
+		return "", nil, err
+	}
+
+	var listen []socketListen
+	if err := getProperty(ctx, obj, "org.freedesktop.systemd1.Socket", "Listen", &listen); err != nil {
+		return "", nil, err
+	}
+
+	return state, listen, nil
+}
+
+// Close closes the D-Bus connection.
+func (b *dbusSystemdBus) Close() error {
+	return b.conn.Close()
+}
+
+// getProperty reads a D-Bus property into dest.
+func getProperty(ctx context.Context, obj dbus.BusObject, iface, property string, dest any) error {
+	var value dbus.Variant
+	err := obj.CallWithContext(ctx, "org.freedesktop.DBus.Properties.Get", 0, iface, property).Store(&value)
+	if err != nil {
+		return fmt.Errorf("failed to read %s.%s: %w", iface, property, err)
+	}
+	if err := value.Store(dest); err != nil {
+		return fmt.Errorf("failed to decode %s.%s: %w", iface, property, err)
+	}
+	return nil
+}
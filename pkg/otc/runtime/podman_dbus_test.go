@@ -0,0 +1,126 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeSystemdBus answers socket unit queries from a fixed table.
+type fakeSystemdBus struct {
+	units  map[string]fakeSocketUnit
+	closed bool
+}
+
+// fakeSocketUnit is the state of one systemd socket unit.
+type fakeSocketUnit struct {
+	state  string
+	listen []socketListen
+}
+
+func (b *fakeSystemdBus) socketUnit(_ context.Context, unit string) (string, []socketListen, error) {
+	u, ok := b.units[unit]
+	if !ok {
+		return "", nil, errors.New("org.freedesktop.systemd1.NoSuchUnit")
+	}
+	return u.state, u.listen, nil
+}
+
+func (b *fakeSystemdBus) Close() error {
+	b.closed = true
+	return nil
+}
+
+// connectTo returns a connector that always yields bus, or err if bus is nil.
+func connectTo(rootless bool, bus *fakeSystemdBus) busConnector {
+	return busConnector{
+		rootless: rootless,
+		connect: func() (systemdBus, error) {
+			if bus == nil {
+				return nil, errors.New("dbus: session bus unavailable")
+			}
+			return bus, nil
+		},
+	}
+}
+
+// fakePodmanDetector is a fallback PodmanDetector returning fixed results.
+type fakePodmanDetector struct {
+	runtimes []Runtime
+	err      error
+}
+
+func (f *fakePodmanDetector) Detect(_ context.Context) ([]Runtime, error) {
+	return f.runtimes, f.err
+}
+
+func TestPodmanDBusDetector_Detect(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+	t.Setenv("PATH", t.TempDir())
+
+	rootlessSocket := &fakeSystemdBus{units: map[string]fakeSocketUnit{
+		podmanSocketUnit: {state: "active", listen: []socketListen{{Type: "Stream", Address: "/run/user/1000/podman/podman.sock"}}},
+	}}
+	rootfulSocket := &fakeSystemdBus{units: map[string]fakeSocketUnit{
+		podmanSocketUnit: {state: "listening", listen: []socketListen{{Type: "Stream", Address: "/run/podman/podman.sock"}}},
+	}}
+	noPodman := &fakeSystemdBus{units: map[string]fakeSocketUnit{}}
+	fallback := &fakePodmanDetector{runtimes: []Runtime{{Name: Podman, Type: TypePodman, Path: "/run/podman/podman.sock"}}}
+
+	tests := []struct {
+		name         string
+		buses        []busConnector
+		fallback     PodmanDetector
+		wantPath     string
+		wantRootless bool
+		wantErr      bool
+	}{
+		{
+			name:         "session bus has rootless podman",
+			buses:        []busConnector{connectTo(true, rootlessSocket), connectTo(false, rootfulSocket)},
+			wantPath:     "/run/user/1000/podman/podman.sock",
+			wantRootless: true,
+		},
+		{
+			name:         "system bus has rootful podman",
+			buses:        []busConnector{connectTo(true, noPodman), connectTo(false, rootfulSocket)},
+			wantPath:     "/run/podman/podman.sock",
+			wantRootless: false,
+		},
+		{
+			name:     "dbus unavailable uses fallback",
+			buses:    []busConnector{connectTo(true, nil), connectTo(false, nil)},
+			fallback: fallback,
+			wantPath: "/run/podman/podman.sock",
+		},
+		{
+			name:    "not found without fallback",
+			buses:   []busConnector{connectTo(true, nil), connectTo(false, noPodman)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := &PodmanDBusDetector{buses: tt.buses, fallback: tt.fallback}
+
+			runtimes, err := detector.Detect(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(runtimes) != 1 || runtimes[0].Path != tt.wantPath {
+				t.Fatalf("Detect() = %+v, want podman at %s", runtimes, tt.wantPath)
+			}
+			if got := runtimes[0].Rootless; got != tt.wantRootless {
+				t.Errorf("Rootless = %v, want %v", got, tt.wantRootless)
+			}
+		})
+	}
+
+	if !rootlessSocket.closed || !noPodman.closed {
+		t.Error("D-Bus connections were not closed")
+	}
+}
@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// startFakePodmanAPI serves the libpod version endpoint on a Unix socket at path.
+func startFakePodmanAPI(t *testing.T, path, version string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create socket dir: %v", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("failed to create Unix socket: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/libpod/version", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"APIVersion":"4.9.3","Version":"` + version + `","GoVersion":"go1.22.1"}`))
+	})
+	server := &http.Server{Handler: mux}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+}
+
+func TestPodmanDetector_Detect(t *testing.T) {
+	// Modifies PATH and XDG_RUNTIME_DIR, so can't run parallel
+
+	tests := []struct {
+		name         string
+		setup        func(t *testing.T, rootful, runtimeDir, binDir string)
+		wantVersion  string
+		wantRootless bool
+		wantCLI      bool
+		wantErr      bool
+	}{
+		{
+			name: "rootful socket",
+			setup: func(t *testing.T, rootful, _, _ string) {
+				startFakePodmanAPI(t, rootful, "4.9.3")
+			},
+			wantVersion:  "4.9.3",
+			wantRootless: false,
+		},
+		{
+			name: "rootless socket",
+			setup: func(t *testing.T, _, runtimeDir, _ string) {
+				startFakePodmanAPI(t, filepath.Join(runtimeDir, "podman", "podman.sock"), "5.2.0")
+			},
+			wantVersion:  "5.2.0",
+			wantRootless: true,
+		},
+		{
+			name: "rootful preferred over rootless",
+			setup: func(t *testing.T, rootful, runtimeDir, _ string) {
+				startFakePodmanAPI(t, rootful, "4.9.3")
+				startFakePodmanAPI(t, filepath.Join(runtimeDir, "podman", "podman.sock"), "5.2.0")
+			},
+			wantVersion:  "4.9.3",
+			wantRootless: false,
+		},
+		{
+			name: "CLI fallback",
+			setup: func(t *testing.T, _, _, binDir string) {
+				writeFakeBinary(t, binDir, Podman, `echo "podman version 4.3.1"`)
+			},
+			wantVersion: "4.3.1",
+			wantCLI:     true,
+		},
+		{
+			name:    "not installed",
+			setup:   func(_ *testing.T, _, _, _ string) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			rootful := filepath.Join(dir, "rootful", "podman.sock")
+			runtimeDir := filepath.Join(dir, "user")
+			binDir := filepath.Join(dir, "bin")
+			if err := os.MkdirAll(binDir, 0755); err != nil {
+				t.Fatalf("failed to create bin dir: %v", err)
+			}
+			t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+			t.Setenv("PATH", binDir)
+			tt.setup(t, rootful, runtimeDir, binDir)
+
+			detector := NewPodmanDetector().(*podmanDetector)
+			detector.rootfulSocket = rootful
+
+			runtimes, err := detector.Detect(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Detect() = %+v, want error", runtimes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			rt := runtimes[0]
+			if rt.Name != Podman || rt.Type != TypePodman || rt.Priority != PriorityPodman {
+				t.Errorf("Detect() = %+v, want podman runtime", rt)
+			}
+			if rt.Version != tt.wantVersion {
+				t.Errorf("Version = %q, want %q", rt.Version, tt.wantVersion)
+			}
+			wantRootless := tt.wantRootless
+			if tt.wantCLI {
+				wantRootless = os.Geteuid() != 0 // Rootless whenever the CLI runs as non-root
+			}
+			if rt.Rootless != wantRootless {
+				t.Errorf("Rootless = %v, want %v", rt.Rootless, wantRootless)
+			}
+			if tt.wantCLI && rt.Path != filepath.Join(binDir, Podman) {
+				t.Errorf("Path = %q, want the podman binary", rt.Path)
+			}
+		})
+	}
+}
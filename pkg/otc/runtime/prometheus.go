@@ -0,0 +1,46 @@
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WritePrometheus writes the detection result as Prometheus text-format gauges,
+// suitable for the node_exporter textfile collector.
+//
+// Emitted metrics:
+//   - otc_runtime_detected{name, type}: 1 for every detected runtime
+//   - otc_runtime_selected{name}: 1 for the selected runtime (omitted if none)
+//   - otc_detection_warnings: number of non-fatal detector errors
+func (r *Result) WritePrometheus(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("# HELP otc_runtime_detected Container runtime detected on this host.\n")
+	b.WriteString("# TYPE otc_runtime_detected gauge\n")
+	for _, rt := range r.Runtimes {
+		fmt.Fprintf(&b, "otc_runtime_detected{name=\"%s\",type=\"%s\"} 1\n",
+			escapeLabelValue(rt.Name), escapeLabelValue(string(rt.Type)))
+	}
+
+	b.WriteString("# HELP otc_runtime_selected Container runtime selected for use.\n")
+	b.WriteString("# TYPE otc_runtime_selected gauge\n")
+	if r.Selected != nil {
+		fmt.Fprintf(&b, "otc_runtime_selected{name=\"%s\"} 1\n", escapeLabelValue(r.Selected.Name))
+	}
+
+	b.WriteString("# HELP otc_detection_warnings Non-fatal errors encountered during detection.\n")
+	b.WriteString("# TYPE otc_detection_warnings gauge\n")
+	fmt.Fprintf(&b, "otc_detection_warnings %d\n", len(r.Warnings))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labelValueEscaper escapes label values per the Prometheus text exposition format
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabelValue escapes backslashes, quotes, and newlines in a label value.
+func escapeLabelValue(v string) string {
+	return labelValueEscaper.Replace(v)
+}
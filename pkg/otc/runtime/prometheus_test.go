@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestResult_WritePrometheus(t *testing.T) {
+	t.Parallel()
+
+	runtimes := []Runtime{
+		{Name: Containerd, Type: TypeCRI, Version: "1.7.0", Priority: PriorityCRI},
+		{Name: Runc, Type: TypeOCI, Version: "1.1.12", Priority: PriorityOCI},
+	}
+
+	tests := []struct {
+		name   string
+		result *Result
+		want   string
+	}{
+		{
+			name: "runtimes with selection and warning",
+			result: &Result{
+				Runtimes: runtimes,
+				Selected: &runtimes[0],
+				Warnings: []error{errors.New("podman socket not found")},
+			},
+			want: `# HELP otc_runtime_detected Container runtime detected on this host.
+# TYPE otc_runtime_detected gauge
+otc_runtime_detected{name="containerd",type="cri"} 1
+otc_runtime_detected{name="runc",type="oci"} 1
+# HELP otc_runtime_selected Container runtime selected for use.
+# TYPE otc_runtime_selected gauge
+otc_runtime_selected{name="containerd"} 1
+# HELP otc_detection_warnings Non-fatal errors encountered during detection.
+# TYPE otc_detection_warnings gauge
+otc_detection_warnings 1
+`,
+		},
+		{
+			name:   "empty result",
+			result: &Result{},
+			want: `# HELP otc_runtime_detected Container runtime detected on this host.
+# TYPE otc_runtime_detected gauge
+# HELP otc_runtime_selected Container runtime selected for use.
+# TYPE otc_runtime_selected gauge
+# HELP otc_detection_warnings Non-fatal errors encountered during detection.
+# TYPE otc_detection_warnings gauge
+otc_detection_warnings 0
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b strings.Builder
+			if err := tt.result.WritePrometheus(&b); err != nil {
+				t.Fatalf("WritePrometheus() error = %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("WritePrometheus() output mismatch\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	t.Parallel()
+
+	got := escapeLabelValue("a\"b\\c\nd")
+	want := `a\"b\\c\nd`
+	if got != want {
+		t.Errorf("escapeLabelValue() = %q, want %q", got, want)
+	}
+}
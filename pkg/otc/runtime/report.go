@@ -0,0 +1,73 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Report formats accepted by WriteReport
+const (
+	ReportTable = "table" // Aligned columns, as written by Result.ToTable
+	ReportJSON  = "json"  // Indented Result JSON, as marshaled by Result.MarshalJSON
+	ReportPlain = "plain" // One Runtime.String line per runtime, then warnings
+)
+
+// WriteReport renders result to w in the given format, so CLIs and embedding
+// tools present detection results identically:
+//
+//   - table: ToTable's aligned columns, the selected runtime marked with "*"
+//   - json: the indented MarshalJSON document
+//   - plain: each runtime's String, the selected one prefixed with "* ",
+//     followed by one "warning: " line per warning
+//
+// Returns error if the format is unknown, result is nil, or writing fails.
+func WriteReport(w io.Writer, result *Result, format string) error {
+	if result == nil {
+		return fmt.Errorf("cannot write report for nil result")
+	}
+
+	switch format {
+	case ReportTable:
+		return result.ToTable(w)
+
+	case ReportJSON:
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal result: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+
+	case ReportPlain:
+		return writePlainReport(w, result)
+
+	default:
+		return fmt.Errorf("unknown report format %q (valid: %s, %s, %s)", format, ReportTable, ReportJSON, ReportPlain)
+	}
+}
+
+// writePlainReport writes result one line per runtime and warning.
+func writePlainReport(w io.Writer, result *Result) error {
+	if result.IsEmpty() {
+		if _, err := fmt.Fprintln(w, "no runtime detected"); err != nil {
+			return err
+		}
+	}
+	for i := range result.Runtimes {
+		rt := &result.Runtimes[i]
+		marker := "  "
+		if result.isSelected(rt) {
+			marker = "* "
+		}
+		if _, err := fmt.Fprintf(w, "%s%s\n", marker, rt); err != nil {
+			return err
+		}
+	}
+	for _, warning := range result.Warnings {
+		if _, err := fmt.Fprintf(w, "warning: %v\n", warning); err != nil {
+			return err
+		}
+	}
+	return nil
+}
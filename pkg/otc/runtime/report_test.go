@@ -0,0 +1,104 @@
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteReport(t *testing.T) {
+	t.Parallel()
+
+	runtimes := []Runtime{
+		{Name: Containerd, Type: TypeCRI, Version: "1.7.0", Path: "/run/containerd/containerd.sock", Priority: PriorityCRI},
+		{Name: Runc, Type: TypeOCI, Version: "1.1.12", Path: "/usr/bin/runc", Priority: PriorityOCI},
+	}
+	result := &Result{
+		Runtimes: runtimes,
+		Selected: &runtimes[0],
+		Warnings: []error{errors.New("crio socket not found")},
+	}
+
+	tests := []struct {
+		name   string
+		result *Result
+		format string
+		want   string
+	}{
+		{
+			name:   "table",
+			result: result,
+			format: ReportTable,
+			want: `NAME        TYPE  VERSION  PATH                             PRIORITY  SELECTED
+containerd  cri   1.7.0    /run/containerd/containerd.sock  100       *
+runc        oci   1.1.12   /usr/bin/runc                    70        
+`,
+		},
+		{
+			name:   "plain",
+			result: result,
+			format: ReportPlain,
+			want: `* containerd (cri) v1.7.0 at /run/containerd/containerd.sock [priority 100]
+  runc (oci) v1.1.12 at /usr/bin/runc [priority 70]
+warning: crio socket not found
+`,
+		},
+		{
+			name:   "plain empty",
+			result: &Result{},
+			format: ReportPlain,
+			want:   "no runtime detected\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b strings.Builder
+			if err := WriteReport(&b, tt.result, tt.format); err != nil {
+				t.Fatalf("WriteReport() error = %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("WriteReport() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteReport_JSON(t *testing.T) {
+	t.Parallel()
+
+	runtimes := []Runtime{{Name: Runc, Type: TypeOCI, Version: "1.1.12", Path: "/usr/bin/runc", Priority: PriorityOCI}}
+	result := &Result{Runtimes: runtimes, Selected: &runtimes[0]}
+
+	var b strings.Builder
+	if err := WriteReport(&b, result, ReportJSON); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+
+	var decoded resultJSON
+	if err := json.Unmarshal([]byte(b.String()), &decoded); err != nil {
+		t.Fatalf("WriteReport() wrote invalid JSON: %v\n%s", err, b.String())
+	}
+	if decoded.Selected == nil || decoded.Selected.Name != Runc {
+		t.Errorf("selected = %+v, want runc", decoded.Selected)
+	}
+	if !strings.HasSuffix(b.String(), "}\n") {
+		t.Error("WriteReport() JSON does not end with a newline")
+	}
+}
+
+func TestWriteReport_Errors(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	if err := WriteReport(&b, &Result{}, "yaml"); err == nil || !strings.Contains(err.Error(), "unknown report format") {
+		t.Errorf("WriteReport() unknown format error = %v", err)
+	}
+	if err := WriteReport(&b, nil, ReportTable); err == nil {
+		t.Error("WriteReport() with nil result returned no error")
+	}
+}
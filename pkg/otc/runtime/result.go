@@ -0,0 +1,122 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// NewResult creates a Result from detected runtimes in ModeAuto.
+// Runtimes are sorted by priority (highest first, stable) and the first one is
+// selected, skipping standalone Wasm runtimes; Selected is nil when no other
+// runtime remains. The slice is sorted in place.
+func NewResult(runtimes []Runtime, warnings []error) *Result {
+	sortByPriority(runtimes)
+
+	result := &Result{
+		Runtimes: runtimes,
+		Warnings: warnings,
+		Mode:     ModeAuto,
+	}
+	result.Selected = SelectHighestPriority(runtimes)
+	return result
+}
+
+// stamp records that detection started at start and has just finished.
+func (r *Result) stamp(start time.Time) {
+	r.DetectedAt = start
+	r.Duration = time.Since(start)
+}
+
+// SelectBy picks a runtime from Runtimes using strategy, e.g.
+// result.SelectBy(SelectByType(TypeCRI)). It returns a pointer into Runtimes,
+// or nil if the strategy selects none. Selected is left unchanged; the
+// default selection remains SelectHighestPriority.
+func (r *Result) SelectBy(strategy SelectionStrategy) *Runtime {
+	return strategy(r.Runtimes)
+}
+
+// ResultBuilder constructs a Result for tests and fake detectors.
+// The zero value is ready to use. Build applies the same sorting and
+// selection as Detect, so hand-built results respect its invariants.
+type ResultBuilder struct {
+	runtimes       []Runtime
+	warnings       []error
+	mode           Mode
+	kubernetesNode bool
+}
+
+// AddRuntime appends a detected runtime.
+func (b *ResultBuilder) AddRuntime(rt Runtime) *ResultBuilder {
+	b.runtimes = append(b.runtimes, rt)
+	return b
+}
+
+// WithWarning appends a non-fatal detection error.
+func (b *ResultBuilder) WithWarning(err error) *ResultBuilder {
+	b.warnings = append(b.warnings, err)
+	return b
+}
+
+// WithMode sets the detection mode. The default is ModeAuto.
+func (b *ResultBuilder) WithMode(mode Mode) *ResultBuilder {
+	b.mode = mode
+	return b
+}
+
+// WithKubernetesNode marks the result as coming from a Kubernetes node.
+func (b *ResultBuilder) WithKubernetesNode() *ResultBuilder {
+	b.kubernetesNode = true
+	return b
+}
+
+// Build returns the Result, sorted by priority with the first runtime selected.
+// An override result is instead ordered like an OTC_RUNTIME list, by the order
+// runtime names were first added, and the first listed name found is selected.
+// Returns error if a runtime lacks a name or type, the mode is unknown, or an
+// override result contains no runtime.
+func (b *ResultBuilder) Build() (*Result, error) {
+	var errs []error
+	var names []string
+	for i, rt := range b.runtimes {
+		if rt.Name == "" {
+			errs = append(errs, fmt.Errorf("runtime %d has empty Name", i))
+		}
+		if rt.Type == "" {
+			errs = append(errs, fmt.Errorf("runtime %q has empty Type", rt.Name))
+		}
+		if !slices.Contains(names, rt.Name) {
+			names = append(names, rt.Name)
+		}
+	}
+
+	mode := b.mode
+	switch mode {
+	case "":
+		mode = ModeAuto
+	case ModeAuto:
+	case ModeOverride:
+		if len(names) == 0 {
+			errs = append(errs, fmt.Errorf("override result must contain at least one runtime"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown mode %q", mode))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid result: %w", err)
+	}
+
+	// Copy so later AddRuntime calls don't alias the built result
+	runtimes := append([]Runtime(nil), b.runtimes...)
+	warnings := append([]error(nil), b.warnings...)
+
+	result := NewResult(runtimes, warnings)
+	result.Mode = mode
+	result.KubernetesNode = b.kubernetesNode
+	if mode == ModeOverride {
+		result.selectInListOrder(names)
+	}
+	return result, nil
+}
@@ -0,0 +1,242 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNewResult(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		runtimes     []Runtime
+		wantSelected string
+		wantOrder    []string
+	}{
+		{
+			name:         "no runtimes",
+			runtimes:     nil,
+			wantSelected: "",
+			wantOrder:    nil,
+		},
+		{
+			name: "sorted by priority",
+			runtimes: []Runtime{
+				{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+				{Name: Podman, Type: TypePodman, Priority: PriorityPodman},
+				{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI},
+				{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+			},
+			wantSelected: Containerd,
+			wantOrder:    []string{Containerd, Runc, Crun, Podman},
+		},
+		{
+			name:         "only wasmedge",
+			runtimes:     []Runtime{{Name: WasmEdge, Type: TypeWasm, Priority: PriorityWasm}},
+			wantSelected: "",
+			wantOrder:    []string{WasmEdge},
+		},
+		{
+			name: "wasmedge outranking runc is skipped",
+			runtimes: []Runtime{
+				{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+				{Name: WasmEdge, Type: TypeWasm, Priority: PriorityCRI},
+			},
+			wantSelected: Runc,
+			wantOrder:    []string{WasmEdge, Runc},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := NewResult(tt.runtimes, nil)
+			if result.Mode != ModeAuto {
+				t.Errorf("Mode = %q, want %q", result.Mode, ModeAuto)
+			}
+
+			var order []string
+			for _, rt := range result.Runtimes {
+				order = append(order, rt.Name)
+			}
+			if len(order) != len(tt.wantOrder) {
+				t.Fatalf("Runtimes = %v, want %v", order, tt.wantOrder)
+			}
+			for i := range order {
+				if order[i] != tt.wantOrder[i] {
+					t.Fatalf("Runtimes = %v, want %v", order, tt.wantOrder)
+				}
+			}
+
+			if tt.wantSelected == "" {
+				if result.Selected != nil {
+					t.Errorf("Selected = %+v, want nil", result.Selected)
+				}
+				return
+			}
+			pointsIntoRuntimes := false
+			for i := range result.Runtimes {
+				pointsIntoRuntimes = pointsIntoRuntimes || result.Selected == &result.Runtimes[i]
+			}
+			if !pointsIntoRuntimes {
+				t.Error("Selected does not point into Runtimes")
+			}
+			if result.Selected.Name != tt.wantSelected {
+				t.Errorf("Selected = %s, want %s", result.Selected.Name, tt.wantSelected)
+			}
+		})
+	}
+}
+
+func TestResultBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	warning := errors.New("CRI socket not found")
+
+	result, err := new(ResultBuilder).
+		AddRuntime(Runtime{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}).
+		AddRuntime(Runtime{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}).
+		WithWarning(warning).
+		WithKubernetesNode().
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if result.Selected == nil || result.Selected.Name != Containerd {
+		t.Errorf("Selected = %+v, want containerd", result.Selected)
+	}
+	if result.Mode != ModeAuto {
+		t.Errorf("Mode = %q, want %q", result.Mode, ModeAuto)
+	}
+	if !result.KubernetesNode {
+		t.Error("KubernetesNode = false, want true")
+	}
+	if len(result.Warnings) != 1 || !errors.Is(result.Warnings[0], warning) {
+		t.Errorf("Warnings = %v, want [%v]", result.Warnings, warning)
+	}
+}
+
+func TestResultBuilder_Build_Invariants(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		builder *ResultBuilder
+		wantErr bool
+	}{
+		{
+			name:    "empty result",
+			builder: new(ResultBuilder),
+			wantErr: false,
+		},
+		{
+			name: "override with one runtime",
+			builder: new(ResultBuilder).
+				AddRuntime(Runtime{Name: Crun, Type: TypeOCI}).
+				WithMode(ModeOverride),
+			wantErr: false,
+		},
+		{
+			name:    "runtime without name",
+			builder: new(ResultBuilder).AddRuntime(Runtime{Type: TypeOCI}),
+			wantErr: true,
+		},
+		{
+			name:    "runtime without type",
+			builder: new(ResultBuilder).AddRuntime(Runtime{Name: Runc}),
+			wantErr: true,
+		},
+		{
+			name:    "override without runtimes",
+			builder: new(ResultBuilder).WithMode(ModeOverride),
+			wantErr: true,
+		},
+		{
+			name:    "unknown mode",
+			builder: new(ResultBuilder).WithMode("manual"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResultBuilder_Build_OverrideList(t *testing.T) {
+	t.Parallel()
+
+	// As for OTC_RUNTIME=runc,containerd: list order beats priority
+	result, err := new(ResultBuilder).
+		AddRuntime(Runtime{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}).
+		AddRuntime(Runtime{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI}).
+		WithMode(ModeOverride).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	var names []string
+	for _, rt := range result.Runtimes {
+		names = append(names, rt.Name)
+	}
+	if want := []string{Runc, Containerd}; !slices.Equal(names, want) {
+		t.Errorf("Runtimes = %v, want %v", names, want)
+	}
+	if result.Selected == nil || result.Selected.Name != Runc {
+		t.Errorf("Selected = %+v, want runc", result.Selected)
+	}
+	if result.Mode != ModeOverride {
+		t.Errorf("Mode = %q, want %q", result.Mode, ModeOverride)
+	}
+}
+
+func TestDetector_Detect_RecordsTiming(t *testing.T) {
+	t.Parallel()
+
+	detector := NewDetector(&fakeOCIDetector{runtimes: []Runtime{{Name: Runc, Type: TypeOCI, Priority: PriorityOCI}}}, nil, nil,
+		WithOverride(""))
+
+	before := time.Now()
+	detectors := map[string]func() (*Result, error){
+		"Detect":    func() (*Result, error) { return detector.Detect(context.Background()) },
+		"DetectAll": func() (*Result, error) { return detector.DetectAll(context.Background()) },
+		"DetectWithStrategy": func() (*Result, error) {
+			return detector.DetectWithStrategy(context.Background(), SelectHighestPriority)
+		},
+	}
+
+	for name, detect := range detectors {
+		result, err := detect()
+		if err != nil {
+			t.Fatalf("%s() error = %v", name, err)
+		}
+		after := time.Now()
+
+		if result.DetectedAt.Before(before) || result.DetectedAt.After(after) {
+			t.Errorf("%s() DetectedAt = %v, want between %v and %v", name, result.DetectedAt, before, after)
+		}
+		if result.Duration < 0 || result.Duration > after.Sub(before) {
+			t.Errorf("%s() Duration = %v, want within %v", name, result.Duration, after.Sub(before))
+		}
+	}
+
+	if result := NewResult(nil, nil); !result.DetectedAt.IsZero() || result.Duration != 0 {
+		t.Errorf("NewResult() timing = %v, %v, want zero", result.DetectedAt, result.Duration)
+	}
+}
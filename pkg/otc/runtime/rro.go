@@ -0,0 +1,94 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// rroMinKernel is the first kernel with mount_setattr(2) MOUNT_ATTR_RDONLY
+// recursion, which recursive read-only mounts depend on.
+var rroMinKernel = [2]int{5, 12}
+
+// kernelSupportsRRO reports whether the running kernel (from
+// procRoot/sys/kernel/osrelease) is new enough for recursive read-only mounts.
+// ok is false when the release cannot be read or parsed.
+func kernelSupportsRRO(procRoot string) (supported, ok bool) {
+	data, err := os.ReadFile(filepath.Join(procRoot, "sys", "kernel", "osrelease"))
+	if err != nil {
+		return false, false
+	}
+
+	major, minor, ok := parseKernelRelease(strings.TrimSpace(string(data)))
+	if !ok {
+		return false, false
+	}
+	if major != rroMinKernel[0] {
+		return major > rroMinKernel[0], true
+	}
+	return minor >= rroMinKernel[1], true
+}
+
+// parseKernelRelease extracts the major and minor numbers from a kernel
+// release such as "6.8.0-45-generic".
+func parseKernelRelease(release string) (major, minor int, ok bool) {
+	parts := strings.SplitN(release, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	// The minor may carry a suffix when there is no patch level (e.g. "5.15-rc1")
+	digits := strings.IndexFunc(parts[1], func(r rune) bool { return r < '0' || r > '9' })
+	if digits == -1 {
+		digits = len(parts[1])
+	}
+	minor, err = strconv.Atoi(parts[1][:digits])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// criRROMounts reports recursive read-only mount support for a CRI runtime:
+// the default handler must advertise recursive_read_only_mounts and, for a
+// local daemon, the kernel must be 5.12+. ok is false when the runtime reports
+// no handlers (before Kubernetes 1.30) or the local kernel can't be determined.
+func criRROMounts(handlers []*runtimeapi.RuntimeHandler, procRoot string, remote bool) (supported, ok bool) {
+	idx := slices.IndexFunc(handlers, func(h *runtimeapi.RuntimeHandler) bool {
+		return h.GetName() == ""
+	})
+	if idx == -1 {
+		return false, false
+	}
+	if !handlers[idx].GetFeatures().GetRecursiveReadOnlyMounts() {
+		return false, true
+	}
+	if remote {
+		return true, true // The daemon checked its own kernel before advertising the feature
+	}
+	return kernelSupportsRRO(procRoot)
+}
+
+// ociRROMounts reports recursive read-only mount support for an OCI runtime
+// from its rroMountOption capability (whether the features document lists
+// the "rro" mount option) plus the kernel version. ok is false when the
+// runtime doesn't list mount options or the kernel can't be determined.
+func ociRROMounts(caps map[string]string, procRoot string) (supported, ok bool) {
+	option, listed := caps["rroMountOption"]
+	if !listed {
+		return false, false
+	}
+	if option != "true" {
+		return false, true
+	}
+	return kernelSupportsRRO(procRoot)
+}
@@ -0,0 +1,150 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+func TestKernelSupportsRRO(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		release       string
+		wantSupported bool
+		wantOK        bool
+	}{
+		{name: "distribution kernel", release: "6.8.0-45-generic\n", wantSupported: true, wantOK: true},
+		{name: "minimum kernel", release: "5.12.0\n", wantSupported: true, wantOK: true},
+		{name: "release candidate", release: "5.15-rc1\n", wantSupported: true, wantOK: true},
+		{name: "too old", release: "5.10.0-28-amd64\n", wantSupported: false, wantOK: true},
+		{name: "unparseable", release: "custom\n", wantSupported: false, wantOK: false},
+		{name: "unreadable", release: "", wantSupported: false, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			procRoot := t.TempDir()
+			if tt.release != "" {
+				writeProcEntry(t, procRoot, "sys/kernel", map[string]string{"osrelease": tt.release})
+			}
+
+			supported, ok := kernelSupportsRRO(procRoot)
+			if supported != tt.wantSupported || ok != tt.wantOK {
+				t.Errorf("kernelSupportsRRO() = (%v, %v), want (%v, %v)",
+					supported, ok, tt.wantSupported, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseFeatures_RROMountOption(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{name: "rro listed", input: `{"mountOptions": ["ro", "rro", "bind"]}`, want: "true", wantOK: true},
+		{name: "rro missing", input: `{"mountOptions": ["ro", "bind"]}`, want: "false", wantOK: true},
+		{name: "no mount options", input: `{}`, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			caps, err := parseFeatures([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("parseFeatures() error = %v", err)
+			}
+			got, ok := caps["rroMountOption"]
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("rroMountOption = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestOCIRROMounts(t *testing.T) {
+	t.Parallel()
+
+	procRoot := t.TempDir()
+	writeProcEntry(t, procRoot, "sys/kernel", map[string]string{"osrelease": "6.1.0\n"})
+
+	tests := []struct {
+		name          string
+		caps          map[string]string
+		wantSupported bool
+		wantOK        bool
+	}{
+		{name: "runtime and kernel support", caps: map[string]string{"rroMountOption": "true"}, wantSupported: true, wantOK: true},
+		{name: "runtime lacks rro", caps: map[string]string{"rroMountOption": "false"}, wantSupported: false, wantOK: true},
+		{name: "mount options not listed", caps: map[string]string{}, wantSupported: false, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			supported, ok := ociRROMounts(tt.caps, procRoot)
+			if supported != tt.wantSupported || ok != tt.wantOK {
+				t.Errorf("ociRROMounts() = (%v, %v), want (%v, %v)",
+					supported, ok, tt.wantSupported, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_RROMounts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		release string
+		feature bool
+		want    string
+	}{
+		{name: "handler feature on new kernel", release: "6.8.0\n", feature: true, want: "true"},
+		{name: "handler feature on old kernel", release: "5.4.0\n", feature: true, want: "false"},
+		{name: "handler without feature", release: "6.8.0\n", feature: false, want: "false"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := &fakeRuntimeService{
+				version: "2.0.0",
+				handlers: []*runtimeapi.RuntimeHandler{
+					{Features: &runtimeapi.RuntimeHandlerFeatures{RecursiveReadOnlyMounts: tt.feature}},
+				},
+			}
+
+			procRoot := t.TempDir()
+			writeProcEntry(t, procRoot, "sys/kernel", map[string]string{"osrelease": tt.release})
+
+			detector := NewContainerdDetector()
+			detector.socketPaths = []string{startFakeCRIServer(t, svc, nil)}
+			detector.procRoot = procRoot
+
+			runtimes, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if got := runtimes[0].Capabilities["rroMounts"]; got != tt.want {
+				t.Errorf("rroMounts = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
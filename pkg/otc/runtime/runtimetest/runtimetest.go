@@ -0,0 +1,87 @@
+// Package runtimetest provides fake runtime detectors for testing code that
+// consumes the runtime package without probing the real system.
+package runtimetest
+
+import (
+	"context"
+
+	"github.com/mango-habanero/otc/pkg/otc/runtime"
+)
+
+// FakeOCIDetector is a runtime.OCIDetector that reports fixed results.
+type FakeOCIDetector struct {
+	Runtimes []runtime.Runtime
+	Err      error
+}
+
+// Detect returns a copy of Runtimes along with Err.
+func (f *FakeOCIDetector) Detect(_ context.Context) ([]runtime.Runtime, error) {
+	return clone(f.Runtimes), f.Err
+}
+
+// FakeCRIDetector is a runtime.CRIDetector that reports fixed results.
+type FakeCRIDetector struct {
+	Runtimes []runtime.Runtime
+	Err      error
+}
+
+// Detect returns a copy of Runtimes along with Err.
+func (f *FakeCRIDetector) Detect(_ context.Context) ([]runtime.Runtime, error) {
+	return clone(f.Runtimes), f.Err
+}
+
+// FakePodmanDetector is a runtime.PodmanDetector that reports fixed results.
+type FakePodmanDetector struct {
+	Runtimes []runtime.Runtime
+	Err      error
+}
+
+// Detect returns a copy of Runtimes along with Err.
+func (f *FakePodmanDetector) Detect(_ context.Context) ([]runtime.Runtime, error) {
+	return clone(f.Runtimes), f.Err
+}
+
+// FakeDockerDetector is a runtime.DockerDetector that reports fixed results.
+type FakeDockerDetector struct {
+	Runtimes []runtime.Runtime
+	Err      error
+}
+
+// Detect returns a copy of Runtimes along with Err.
+func (f *FakeDockerDetector) Detect(_ context.Context) ([]runtime.Runtime, error) {
+	return clone(f.Runtimes), f.Err
+}
+
+// NewDetectorFromRuntimes returns a Detector for a simulated host on which
+// exactly rts are installed, e.g. NewDetectorFromRuntimes(runtime.Runtime{Name:
+// runtime.Crun, Type: runtime.TypeOCI, Priority: runtime.PriorityOCI}) for a
+// host with only crun. Each runtime is reported by the fake detector for its
+// Type; Wasm runtimes come from the OCI detector, as on a real host. OTC_RUNTIME
+// is ignored and host checks are skipped, so results don't depend on the test
+// environment.
+func NewDetectorFromRuntimes(rts ...runtime.Runtime) *runtime.Detector {
+	oci, cri := &FakeOCIDetector{}, &FakeCRIDetector{}
+	podman, docker := &FakePodmanDetector{}, &FakeDockerDetector{}
+
+	for _, rt := range rts {
+		switch rt.Type {
+		case runtime.TypeCRI:
+			cri.Runtimes = append(cri.Runtimes, rt)
+		case runtime.TypePodman:
+			podman.Runtimes = append(podman.Runtimes, rt)
+		case runtime.TypeDocker:
+			docker.Runtimes = append(docker.Runtimes, rt)
+		default:
+			oci.Runtimes = append(oci.Runtimes, rt)
+		}
+	}
+
+	return runtime.NewDetector(oci, cri, podman,
+		runtime.WithDockerDetector(docker), runtime.WithOverride(""),
+		runtime.WithoutHostChecks())
+}
+
+// clone copies runtimes so callers can't modify a fake's configured results.
+func clone(runtimes []runtime.Runtime) []runtime.Runtime {
+	return append([]runtime.Runtime(nil), runtimes...)
+}
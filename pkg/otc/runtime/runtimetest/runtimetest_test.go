@@ -0,0 +1,130 @@
+package runtimetest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mango-habanero/otc/pkg/otc/runtime"
+)
+
+// Compile-time checks that the fakes implement the detector interfaces
+var (
+	_ runtime.OCIDetector    = (*FakeOCIDetector)(nil)
+	_ runtime.CRIDetector    = (*FakeCRIDetector)(nil)
+	_ runtime.PodmanDetector = (*FakePodmanDetector)(nil)
+	_ runtime.DockerDetector = (*FakeDockerDetector)(nil)
+)
+
+func TestNewDetectorFromRuntimes(t *testing.T) {
+	t.Parallel()
+
+	crun := runtime.Runtime{Name: runtime.Crun, Type: runtime.TypeOCI, Version: "1.14.4", Priority: runtime.PriorityOCI}
+	containerd := runtime.Runtime{Name: runtime.Containerd, Type: runtime.TypeCRI, Version: "1.7.13", Priority: runtime.PriorityCRI}
+	podman := runtime.Runtime{Name: runtime.Podman, Type: runtime.TypePodman, Version: "4.9.3", Priority: runtime.PriorityPodman}
+
+	tests := []struct {
+		name         string
+		runtimes     []runtime.Runtime
+		wantSelected string
+		wantCount    int
+	}{
+		{
+			name:         "only crun",
+			runtimes:     []runtime.Runtime{crun},
+			wantSelected: runtime.Crun,
+			wantCount:    1,
+		},
+		{
+			name:         "CRI preferred",
+			runtimes:     []runtime.Runtime{crun, podman, containerd},
+			wantSelected: runtime.Containerd,
+			wantCount:    3,
+		},
+		{
+			name: "empty host",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewDetectorFromRuntimes(tt.runtimes...).Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if tt.wantSelected == "" {
+				if !result.IsEmpty() || result.Selected != nil {
+					t.Errorf("Detect() = %v, want an empty result", result)
+				}
+				return
+			}
+			if result.Selected.Name != tt.wantSelected {
+				t.Errorf("Selected = %s, want %s", result.Selected.Name, tt.wantSelected)
+			}
+			if result.RuntimeCount() != tt.wantCount {
+				t.Errorf("RuntimeCount() = %d, want %d", result.RuntimeCount(), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestNewDetectorFromRuntimes_NoHostChecks(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	// A systemctl reporting every unit active, as on a host running containerd
+	binDir := t.TempDir()
+	systemctl := filepath.Join(binDir, "systemctl")
+	if err := os.WriteFile(systemctl, []byte("#!/bin/sh\necho active\n"), 0755); err != nil {
+		t.Fatalf("failed to write fake systemctl: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	containerd := runtime.Runtime{
+		Name:     runtime.Containerd,
+		Type:     runtime.TypeCRI,
+		Version:  "1.7.13",
+		Priority: runtime.PriorityCRI,
+	}
+
+	result, err := NewDetectorFromRuntimes(containerd).Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if state, ok := result.Selected.Capabilities["serviceState"]; ok {
+		t.Errorf("Capabilities[serviceState] = %q, want unset", state)
+	}
+	if result.KubernetesNode {
+		t.Error("KubernetesNode = true, want false")
+	}
+}
+
+func TestFakeDetectors(t *testing.T) {
+	t.Parallel()
+
+	detectErr := errors.New("socket not found")
+	cri := &FakeCRIDetector{Err: detectErr}
+	oci := &FakeOCIDetector{Runtimes: []runtime.Runtime{{Name: runtime.Runc, Type: runtime.TypeOCI, Priority: runtime.PriorityOCI}}}
+
+	result, err := runtime.NewDetector(oci, cri, nil, runtime.WithOverride("")).Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if result.Selected.Name != runtime.Runc {
+		t.Errorf("Selected = %s, want runc", result.Selected.Name)
+	}
+	if len(result.Warnings) != 1 || !errors.Is(result.Warnings[0], detectErr) {
+		t.Errorf("Warnings = %v, want the CRI detector error", result.Warnings)
+	}
+
+	// Results are copies, so callers can't change what the fake reports
+	got, _ := oci.Detect(context.Background())
+	got[0].Name = "mutated"
+	if oci.Runtimes[0].Name != runtime.Runc {
+		t.Error("modifying Detect() result changed the fake's Runtimes")
+	}
+}
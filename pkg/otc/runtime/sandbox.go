@@ -0,0 +1,71 @@
+package runtime
+
+import (
+	"net"
+	"strings"
+)
+
+// unreachableRegistries are registries known not to serve pause images to nodes.
+// k8s.gcr.io was frozen in favor of registry.k8s.io.
+var unreachableRegistries = map[string]string{
+	"k8s.gcr.io": "registry is frozen; use registry.k8s.io",
+}
+
+// checkSandboxImage warns about CRI runtimes whose configured pause/sandbox image
+// is empty or points at a registry nodes can't pull from. A bad sandbox image
+// prevents every pod on the node from starting.
+func checkSandboxImage(runtimes []Runtime) []error {
+	var warnings []error
+	for _, rt := range runtimes {
+		image, ok := rt.Capabilities["sandboxImage"]
+		if !ok {
+			continue // Config not readable
+		}
+
+		// Without one the runtime's built-in default applies, which may not
+		// match what the cluster mirrors
+		if image == "" {
+			warnings = append(warnings, newWarning(SeverityMedium, "%s has no sandbox image configured", rt.Name))
+			continue
+		}
+
+		if reason := unreachableRegistry(imageRegistry(image)); reason != "" {
+			warnings = append(warnings, newWarning(SeverityHigh, "%s sandbox image %s is likely unpullable: %s",
+				rt.Name, image, reason))
+		}
+	}
+	return warnings
+}
+
+// imageRegistry returns the registry host of an image reference.
+// References without an explicit registry resolve to docker.io.
+func imageRegistry(image string) string {
+	first, _, found := strings.Cut(image, "/")
+	if !found {
+		return "docker.io"
+	}
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		return first
+	}
+	return "docker.io"
+}
+
+// unreachableRegistry explains why a registry is obviously unreachable from a node,
+// or returns "" if there's no known problem.
+func unreachableRegistry(registry string) string {
+	if reason, ok := unreachableRegistries[registry]; ok {
+		return reason
+	}
+
+	host := registry
+	if h, _, err := net.SplitHostPort(registry); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return "registry is on loopback"
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return "registry is on loopback"
+	}
+	return ""
+}
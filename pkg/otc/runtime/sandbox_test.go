@@ -0,0 +1,77 @@
+package runtime
+
+import "testing"
+
+func TestCheckSandboxImage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		caps      map[string]string
+		wantWarns int
+		wantMsg   string
+		wantSev   Severity
+	}{
+		{
+			name:      "valid image",
+			caps:      map[string]string{"sandboxImage": "registry.k8s.io/pause:3.9"},
+			wantWarns: 0,
+		},
+		{
+			name:      "docker hub image",
+			caps:      map[string]string{"sandboxImage": "rancher/mirrored-pause:3.6"},
+			wantWarns: 0,
+		},
+		{
+			name:      "config not readable",
+			caps:      nil,
+			wantWarns: 0,
+		},
+		{
+			name:      "empty image",
+			caps:      map[string]string{"sandboxImage": ""},
+			wantWarns: 1,
+			wantMsg:   "no sandbox image configured",
+			wantSev:   SeverityMedium,
+		},
+		{
+			name:      "frozen registry",
+			caps:      map[string]string{"sandboxImage": "k8s.gcr.io/pause:3.2"},
+			wantWarns: 1,
+			wantMsg:   "use registry.k8s.io",
+			wantSev:   SeverityHigh,
+		},
+		{
+			name:      "loopback registry",
+			caps:      map[string]string{"sandboxImage": "127.0.0.1:5000/pause:3.9"},
+			wantWarns: 1,
+			wantMsg:   "registry is on loopback",
+			wantSev:   SeverityHigh,
+		},
+		{
+			name:      "localhost registry",
+			caps:      map[string]string{"sandboxImage": "localhost/pause:3.9"},
+			wantWarns: 1,
+			wantMsg:   "registry is on loopback",
+			wantSev:   SeverityHigh,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			warnings := checkSandboxImage([]Runtime{{Name: Containerd, Type: TypeCRI, Capabilities: tt.caps}})
+			if len(warnings) != tt.wantWarns {
+				t.Fatalf("expected %d warnings, got %d: %v", tt.wantWarns, len(warnings), warnings)
+			}
+			if tt.wantWarns > 0 && !contains(warnings[0].Error(), tt.wantMsg) {
+				t.Errorf("warning %q does not contain %q", warnings[0].Error(), tt.wantMsg)
+			}
+			if tt.wantWarns > 0 && WarningSeverity(warnings[0]) != tt.wantSev {
+				t.Errorf("severity = %v, want %v", WarningSeverity(warnings[0]), tt.wantSev)
+			}
+		})
+	}
+}
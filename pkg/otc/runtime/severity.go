@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Severity ranks how serious a detection warning is.
+type Severity int
+
+const (
+	// SeverityLow marks informational findings that rarely need action
+	SeverityLow Severity = iota
+
+	// SeverityMedium is the default for warnings without an explicit severity
+	SeverityMedium
+
+	// SeverityHigh marks misconfigurations known to break workloads
+	SeverityHigh
+)
+
+// String returns the lowercase name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Warning is a non-fatal detection finding with an explicit severity.
+// It appears in Result.Warnings alongside plain detector errors.
+type Warning struct {
+	Severity Severity
+	Err      error
+}
+
+// Error returns the underlying message.
+func (w *Warning) Error() string {
+	return w.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (w *Warning) Unwrap() error {
+	return w.Err
+}
+
+// newWarning creates a Warning with a formatted message.
+func newWarning(severity Severity, format string, args ...any) *Warning {
+	return &Warning{Severity: severity, Err: fmt.Errorf(format, args...)}
+}
+
+// WarningSeverity returns the severity of a warning from Result.Warnings.
+// Errors that are not a *Warning are SeverityMedium.
+func WarningSeverity(err error) Severity {
+	var w *Warning
+	if errors.As(err, &w) {
+		return w.Severity
+	}
+	return SeverityMedium
+}
+
+// joinWarnings combines warnings into one error for a detector to return
+// alongside its runtimes. A single warning is returned as is; nil for none.
+func joinWarnings(warnings []error) error {
+	switch len(warnings) {
+	case 0:
+		return nil
+	case 1:
+		return warnings[0]
+	default:
+		return errors.Join(warnings...)
+	}
+}
+
+// isWarning reports whether err is or wraps a *Warning.
+// Detectors return a *Warning alongside runtimes that should still be kept.
+func isWarning(err error) bool {
+	var w *Warning
+	return errors.As(err, &w)
+}
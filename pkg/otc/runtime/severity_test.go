@@ -0,0 +1,51 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWarningSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want Severity
+	}{
+		{name: "plain error", err: errors.New("socket not found"), want: SeverityMedium},
+		{name: "low warning", err: newWarning(SeverityLow, "version unknown"), want: SeverityLow},
+		{name: "wrapped high warning", err: fmt.Errorf("check: %w", newWarning(SeverityHigh, "mismatch")), want: SeverityHigh},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := WarningSeverity(tt.err); got != tt.want {
+				t.Errorf("WarningSeverity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinWarnings(t *testing.T) {
+	t.Parallel()
+
+	first := newWarning(SeverityLow, "first")
+	second := newWarning(SeverityMedium, "second")
+
+	if err := joinWarnings(nil); err != nil {
+		t.Errorf("joinWarnings(nil) = %v, want nil", err)
+	}
+	if err := joinWarnings([]error{first}); err != first {
+		t.Errorf("joinWarnings(one) = %v, want the warning itself", err)
+	}
+
+	err := joinWarnings([]error{first, second})
+	if !errors.Is(err, first) || !errors.Is(err, second) || !isWarning(err) {
+		t.Errorf("joinWarnings(two) = %v, want both warnings joined", err)
+	}
+}
@@ -0,0 +1,52 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+)
+
+// getSocketJSON issues a GET for url on the HTTP API served over the Unix
+// socket and decodes the JSON response into v. The URL host is ignored.
+// name identifies the runtime in errors (e.g. "podman").
+func getSocketJSON(ctx context.Context, name, socket, url string, v any) error {
+	info, err := os.Stat(socket)
+	if err != nil {
+		return fmt.Errorf("%s socket %s: %w", name, socket, err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s socket %s: not a socket", name, socket)
+	}
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		},
+	}
+	defer transport.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build %s version request: %w", name, err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return fmt.Errorf("%s version request on %s failed: %w", name, socket, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s version request on %s returned %s", name, socket, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse %s version response: %w", name, err)
+	}
+	return nil
+}
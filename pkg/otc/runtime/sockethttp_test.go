@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// serveSocketHTTP serves handler on a Unix socket at path until the test ends.
+func serveSocketHTTP(t *testing.T, path string, handler http.HandlerFunc) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create socket dir: %v", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("failed to create Unix socket: %v", err)
+	}
+
+	server := &http.Server{Handler: handler}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+}
+
+func TestGetSocketJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc
+		noSocket    bool
+		wantVersion string
+		wantErr     string
+	}{
+		{
+			name: "decodes response",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"Version":"27.3.1"}`))
+			},
+			wantVersion: "27.3.1",
+		},
+		{
+			name: "error status",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "boom", http.StatusInternalServerError)
+			},
+			wantErr: "returned 500 Internal Server Error",
+		},
+		{
+			name: "invalid JSON",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`not json`))
+			},
+			wantErr: "failed to parse docker version response",
+		},
+		{
+			name:     "missing socket",
+			noSocket: true,
+			wantErr:  "docker socket",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			socket := filepath.Join(t.TempDir(), "api.sock")
+			if !tt.noSocket {
+				serveSocketHTTP(t, socket, tt.handler)
+			}
+
+			var body struct {
+				Version string `json:"Version"`
+			}
+			err := getSocketJSON(context.Background(), Docker, socket, dockerVersionURL, &body)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("getSocketJSON() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getSocketJSON() error = %v", err)
+			}
+			if body.Version != tt.wantVersion {
+				t.Errorf("Version = %q, want %q", body.Version, tt.wantVersion)
+			}
+		})
+	}
+}
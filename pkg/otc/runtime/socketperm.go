@@ -0,0 +1,98 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"syscall"
+)
+
+// ErrSocketNotAccessible reports that a runtime socket exists but the current
+// process lacks permission to connect to it. Check it with errors.Is to tell
+// a permissions problem from a runtime that is absent or not answering.
+var ErrSocketNotAccessible = errors.New("not accessible")
+
+// SocketInfo describes the ownership of a runtime's local socket and whether
+// the current process may connect to it.
+type SocketInfo struct {
+	// OwnerUID is the user ID owning the socket file
+	OwnerUID int `json:"ownerUID"`
+
+	// OwnerGID is the group ID owning the socket file
+	OwnerGID int `json:"ownerGID"`
+
+	// Accessible is true when the effective user may connect, which takes
+	// write permission on the socket
+	Accessible bool `json:"accessible"`
+}
+
+// statSocket reports the ownership and accessibility of the socket described by info.
+// ok is false if the platform does not expose file ownership.
+func statSocket(info os.FileInfo) (socket SocketInfo, ok bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return SocketInfo{}, false
+	}
+
+	groups, _ := os.Getgroups()
+	uid, gid := int(st.Uid), int(st.Gid)
+	return SocketInfo{
+		OwnerUID:   uid,
+		OwnerGID:   gid,
+		Accessible: canConnect(info.Mode().Perm(), uid, gid, os.Geteuid(), append(groups, os.Getegid())),
+	}, true
+}
+
+// canConnect reports whether a process with effective user euid and group
+// membership gids may connect to a socket with the given permissions and owner.
+// Connecting needs write permission, checked against the owner, group, or other
+// bits like the kernel does; root bypasses the check.
+func canConnect(perm os.FileMode, uid, gid, euid int, gids []int) bool {
+	switch {
+	case euid == 0:
+		return true
+	case euid == uid:
+		return perm&0200 != 0
+	case slices.Contains(gids, gid):
+		return perm&0020 != 0
+	default:
+		return perm&0002 != 0
+	}
+}
+
+// socketNotAccessibleError describes a socket the current process may not connect to.
+func socketNotAccessibleError(path string, socket SocketInfo, perm os.FileMode) error {
+	return fmt.Errorf("socket %s exists but %w by uid %d (owner %d:%d, mode %s)",
+		path, ErrSocketNotAccessible, os.Geteuid(), socket.OwnerUID, socket.OwnerGID, perm)
+}
+
+// localSocketInfo stats a local socket for Runtime.Socket, returning nil if
+// it cannot be stat'd or the platform does not expose file ownership.
+func localSocketInfo(path string) *SocketInfo {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	socket, ok := statSocket(info)
+	if !ok {
+		return nil
+	}
+	return &socket
+}
+
+// brokenSymlinkWarning returns a warning if path is a symlink whose target does
+// not exist, or nil otherwise. A dangling socket symlink usually means a
+// misconfigured or half-uninstalled runtime, so it is reported rather than
+// skipped like a path that was never there.
+func brokenSymlinkWarning(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil // Target exists
+	}
+	target, _ := os.Readlink(path)
+	return newWarning(SeverityMedium, "socket path %s is a broken symlink to %s", path, target)
+}
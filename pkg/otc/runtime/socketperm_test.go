@@ -0,0 +1,204 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCanConnect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		perm os.FileMode
+		euid int
+		gids []int
+		want bool
+	}{
+		{name: "root bypasses permissions", perm: 0000, euid: 0, want: true},
+		{name: "owner with write", perm: 0600, euid: 1000, want: true},
+		{name: "owner without write", perm: 0400, euid: 1000, want: false},
+		{name: "group member with write", perm: 0660, euid: 1001, gids: []int{100}, want: true},
+		{name: "group member without write", perm: 0600, euid: 1001, gids: []int{100}, want: false},
+		{name: "other with write", perm: 0666, euid: 1001, gids: []int{50}, want: true},
+		{name: "other without write", perm: 0660, euid: 1001, gids: []int{50}, want: false},
+		{name: "owner bits win over other", perm: 0066, euid: 1000, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Socket owned by 1000:100
+			if got := canConnect(tt.perm, 1000, 100, tt.euid, tt.gids); got != tt.want {
+				t.Errorf("canConnect(%s) = %v, want %v", tt.perm, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatSocket(t *testing.T) {
+	t.Parallel()
+
+	socketPath, cleanup := createTestSocket(t, "owned.sock")
+	defer cleanup()
+
+	info, err := os.Stat(socketPath)
+	if err != nil {
+		t.Fatalf("failed to stat socket: %v", err)
+	}
+
+	socket, ok := statSocket(info)
+	if !ok {
+		t.Fatal("statSocket() ok = false, want ownership on this platform")
+	}
+	if socket.OwnerUID != os.Geteuid() || socket.OwnerGID != os.Getegid() {
+		t.Errorf("owner = %d:%d, want %d:%d", socket.OwnerUID, socket.OwnerGID, os.Geteuid(), os.Getegid())
+	}
+	if !socket.Accessible {
+		t.Error("Accessible = false for a socket owned by the current user")
+	}
+}
+
+func TestSocketNotAccessibleError(t *testing.T) {
+	t.Parallel()
+
+	err := socketNotAccessibleError("/run/containerd/containerd.sock", SocketInfo{OwnerUID: 0, OwnerGID: 0}, 0660)
+	if !errors.Is(err, ErrSocketNotAccessible) {
+		t.Errorf("error = %v, want wrapping ErrSocketNotAccessible", err)
+	}
+	want := "socket /run/containerd/containerd.sock exists but not accessible by uid"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %q, want containing %q", err, want)
+	}
+}
+
+func TestContainerdDetector_SocketNotAccessible(t *testing.T) {
+	t.Parallel()
+
+	if os.Geteuid() == 0 {
+		t.Skip("root can connect to any socket")
+	}
+
+	socketPath, cleanup := createTestSocket(t, "containerd.sock")
+	defer cleanup()
+	if err := os.Chmod(socketPath, 0400); err != nil {
+		t.Fatalf("failed to chmod socket: %v", err)
+	}
+
+	detector := NewContainerdDetector()
+	detector.socketPaths = []string{socketPath}
+
+	_, err := detector.Detect(context.Background())
+	if !errors.Is(err, ErrSocketNotAccessible) {
+		t.Errorf("Detect() error = %v, want wrapping ErrSocketNotAccessible", err)
+	}
+	if errors.Is(err, ErrRuntimeNotFound) {
+		t.Errorf("Detect() error = %v, want a permissions error, not ErrRuntimeNotFound", err)
+	}
+}
+
+func TestContainerdDetector_SocketInfo(t *testing.T) {
+	t.Parallel()
+
+	detector := NewContainerdDetector()
+	detector.socketPaths = []string{startFakeCRIServer(t, &fakeRuntimeService{version: "2.0.0"}, nil)}
+
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	socket := runtimes[0].Socket
+	if socket == nil || !socket.Accessible || socket.OwnerUID != os.Geteuid() {
+		t.Errorf("Socket = %+v, want accessible socket owned by uid %d", socket, os.Geteuid())
+	}
+}
+
+func TestBrokenSymlinkWarning(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dangling := filepath.Join(dir, "dangling.sock")
+	if err := os.Symlink(filepath.Join(dir, "gone", "containerd.sock"), dangling); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+	regular := filepath.Join(dir, "regular")
+	if err := os.WriteFile(regular, nil, 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	valid := filepath.Join(dir, "valid.sock")
+	if err := os.Symlink(regular, valid); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "dangling symlink", path: dangling, want: true},
+		{name: "valid symlink", path: valid, want: false},
+		{name: "missing path", path: filepath.Join(dir, "missing.sock"), want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := brokenSymlinkWarning(tt.path)
+			if (err != nil) != tt.want {
+				t.Fatalf("brokenSymlinkWarning() = %v, want warning %v", err, tt.want)
+			}
+			if err != nil && (!isWarning(err) || !strings.Contains(err.Error(), "is a broken symlink")) {
+				t.Errorf("brokenSymlinkWarning() = %v, want broken symlink *Warning", err)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_BrokenSymlinkSocket(t *testing.T) {
+	t.Parallel()
+
+	dangling := filepath.Join(t.TempDir(), "containerd.sock")
+	if err := os.Symlink("/nonexistent/containerd.sock", dangling); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	t.Run("other socket found", func(t *testing.T) {
+		t.Parallel()
+
+		detector := NewContainerdDetector()
+		detector.socketPaths = []string{dangling, startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.20"}, nil)}
+
+		runtimes, err := detector.Detect(context.Background())
+		if len(runtimes) != 1 {
+			t.Fatalf("Detect() = %+v, %v, want containerd", runtimes, err)
+		}
+		if !isWarning(err) || !strings.Contains(err.Error(), dangling+" is a broken symlink") {
+			t.Errorf("Detect() error = %v, want broken symlink warning", err)
+		}
+	})
+
+	t.Run("no socket found", func(t *testing.T) {
+		t.Parallel()
+
+		detector := NewContainerdDetector()
+		detector.socketPaths = []string{dangling}
+
+		_, _, err := detector.findSocket()
+		if err == nil {
+			t.Fatal("findSocket() error = nil, want not found")
+		}
+		// Not cached as missing, so the next search reports it again
+		if _, warnings, _ := detector.findSocket(); len(warnings) != 1 {
+			t.Errorf("findSocket() warnings = %v, want the broken symlink", warnings)
+		}
+	})
+}
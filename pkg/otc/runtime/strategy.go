@@ -0,0 +1,199 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrAmbiguousSelection is returned in strict mode when several runtimes tie
+// for the highest priority and nothing disambiguates between them.
+var ErrAmbiguousSelection = errors.New("ambiguous runtime selection")
+
+// ErrNoRuntimeSelected is returned when detection finds runtimes but the
+// selection strategy accepts none of them, e.g. when Detect finds only
+// standalone Wasm runtimes.
+var ErrNoRuntimeSelected = errors.New("no detected runtime satisfies the selection strategy")
+
+// SelectionStrategy picks one runtime from a list ordered by priority (highest first).
+// It returns a pointer into the given slice, or nil if no runtime qualifies.
+type SelectionStrategy func(runtimes []Runtime) *Runtime
+
+// FallbackChain combines strategies into tiers: each is tried in order and
+// the first non-nil selection wins.
+func FallbackChain(tiers ...SelectionStrategy) SelectionStrategy {
+	return func(runtimes []Runtime) *Runtime {
+		for _, tier := range tiers {
+			if selected := tier(runtimes); selected != nil {
+				return selected
+			}
+		}
+		return nil
+	}
+}
+
+// SelectHighestPriority selects the first runtime, matching Detect's default behavior.
+// Standalone Wasm runtimes can't run containers and are never selected.
+func SelectHighestPriority(runtimes []Runtime) *Runtime {
+	for i := range runtimes {
+		if runtimes[i].Type != TypeWasm {
+			return &runtimes[i]
+		}
+	}
+	return nil
+}
+
+// SelectMatching selects the highest priority runtime for which match returns true.
+// Use it for health-filtered selection by passing a liveness check.
+func SelectMatching(match func(Runtime) bool) SelectionStrategy {
+	return func(runtimes []Runtime) *Runtime {
+		for i := range runtimes {
+			if match(runtimes[i]) {
+				return &runtimes[i]
+			}
+		}
+		return nil
+	}
+}
+
+// SelectByType selects the highest priority runtime of type t,
+// e.g. SelectByType(TypeCRI) to only ever pick a CRI runtime.
+func SelectByType(t Type) SelectionStrategy {
+	return SelectMatching(func(rt Runtime) bool {
+		return rt.Type == t
+	})
+}
+
+// SelectNewestVersion selects the runtime with the newest version, compared
+// as by Runtime.CompareVersion; ties go to the higher priority runtime.
+// Runtimes with unparseable versions and standalone Wasm runtimes are never
+// selected. Versions of different runtimes are compared as-is, so it is most
+// useful on runtimes of one kind, e.g. several installs of the same runtime.
+func SelectNewestVersion(runtimes []Runtime) *Runtime {
+	var newest *Runtime
+	var newestVersion semver
+	for i := range runtimes {
+		if runtimes[i].Type == TypeWasm {
+			continue
+		}
+		v, err := parseSemver(runtimes[i].Version)
+		if err != nil {
+			continue
+		}
+		if newest == nil || v.compare(newestVersion) > 0 {
+			newest, newestVersion = &runtimes[i], v
+		}
+	}
+	return newest
+}
+
+// SelectNamed selects the highest priority runtime with the given name.
+// An empty name never matches, so the tier is skipped.
+func SelectNamed(name string) SelectionStrategy {
+	return SelectMatching(func(rt Runtime) bool {
+		return name != "" && rt.Name == name
+	})
+}
+
+// SelectPinned selects the runtime named by the OTC_RUNTIME environment variable, if any.
+// For a comma-separated list, the first listed runtime that was found wins.
+// The variable is read each time the strategy runs; use Detector.SelectPinned
+// to honor WithOverride too.
+func SelectPinned() SelectionStrategy {
+	return func(runtimes []Runtime) *Runtime {
+		return selectListed(parseOverride(getOverrideFromEnv()), runtimes)
+	}
+}
+
+// SelectPinned selects the runtime named by the detector's override, set by
+// WithOverride or read from OTC_RUNTIME at construction. For a comma-separated
+// list, the first listed runtime that was found wins.
+func (d *Detector) SelectPinned() SelectionStrategy {
+	names := parseOverride(d.override)
+	return func(runtimes []Runtime) *Runtime {
+		return selectListed(names, runtimes)
+	}
+}
+
+// selectListed selects the highest priority runtime with the first of names
+// that matches any runtime, or nil if none does.
+func selectListed(names []string, runtimes []Runtime) *Runtime {
+	for _, name := range names {
+		if selected := SelectNamed(name)(runtimes); selected != nil {
+			return selected
+		}
+	}
+	return nil
+}
+
+// SelectWithCapability selects the highest priority runtime reporting
+// Capabilities[key] == value.
+func SelectWithCapability(key, value string) SelectionStrategy {
+	return SelectMatching(func(rt Runtime) bool {
+		v, ok := rt.Capabilities[key]
+		return ok && v == value
+	})
+}
+
+// checkAmbiguous returns an error wrapping ErrAmbiguousSelection that lists the
+// candidates if more than one runtime shares the top priority.
+// Runtimes must be sorted by priority.
+func checkAmbiguous(runtimes []Runtime) error {
+	var candidates []string
+	for _, rt := range runtimes {
+		if rt.Priority != runtimes[0].Priority {
+			break
+		}
+		candidates = append(candidates, rt.Name)
+	}
+
+	if len(candidates) > 1 {
+		return fmt.Errorf("%w: %s tie at priority %d", ErrAmbiguousSelection,
+			strings.Join(candidates, ", "), runtimes[0].Priority)
+	}
+	return nil
+}
+
+// DetectWithStrategy detects every available runtime and selects one using strategy.
+// Unlike Detect, the OTC_RUNTIME override does not restrict detection; use
+// SelectPinned as a tier to honor it as a preference. As with Detect, the
+// WithOnRuntimeFound callback sees each runtime as it is found, and result
+// hooks run once strategy has selected, failing the call on error.
+//
+// Returns ErrNoRuntimeSelected if runtimes were found but the strategy selected none.
+func (d *Detector) DetectWithStrategy(ctx context.Context, strategy SelectionStrategy) (*Result, error) {
+	if d.closed.Load() {
+		return nil, ErrDetectorClosed
+	}
+
+	start := time.Now()
+	runtimes, warnings := d.collect(ctx)
+
+	// If no runtimes found, and we have warnings, return the first error
+	if len(runtimes) == 0 && len(warnings) > 0 {
+		return nil, warnings[0]
+	}
+
+	result := &Result{
+		Runtimes:       runtimes,
+		Warnings:       warnings,
+		Mode:           ModeAuto,
+		KubernetesNode: d.isKubernetesNode(),
+	}
+	result.stamp(start)
+
+	if len(runtimes) > 0 {
+		result.Selected = strategy(runtimes)
+		if result.Selected == nil {
+			return nil, ErrNoRuntimeSelected
+		}
+	}
+
+	if err := d.runHooks(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,294 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeOCIDetector returns canned OCI detection results.
+type fakeOCIDetector struct {
+	runtimes []Runtime
+	err      error
+}
+
+func (f *fakeOCIDetector) Detect(_ context.Context) ([]Runtime, error) {
+	return f.runtimes, f.err
+}
+
+// fakeCRIDetector returns canned CRI or Podman detection results.
+type fakeCRIDetector struct {
+	runtimes []Runtime
+	err      error
+}
+
+func (f *fakeCRIDetector) Detect(_ context.Context) ([]Runtime, error) {
+	return f.runtimes, f.err
+}
+
+func TestDetector_DetectWithStrategy(t *testing.T) {
+	// Uses OTC_RUNTIME, so can't run parallel
+
+	detector := &Detector{
+		oci: &fakeOCIDetector{runtimes: []Runtime{
+			{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+			{Name: Crun, Type: TypeOCI, Priority: PriorityOCI, Capabilities: map[string]string{"checkpoint": "true"}},
+		}},
+		cri: &fakeCRIDetector{runtimes: []Runtime{
+			{Name: Containerd, Type: TypeCRI, Priority: PriorityCRI},
+		}},
+	}
+
+	unhealthy := map[string]bool{Containerd: true}
+	healthy := func(rt Runtime) bool { return !unhealthy[rt.Name] }
+
+	chain := FallbackChain(
+		SelectPinned(),
+		SelectWithCapability("checkpoint", "true"),
+		SelectMatching(healthy),
+		SelectHighestPriority,
+	)
+
+	tests := []struct {
+		name     string
+		pinned   string
+		strategy SelectionStrategy
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "pinned tier",
+			pinned:   Runc,
+			strategy: FallbackChain(SelectPinned(), SelectHighestPriority),
+			want:     Runc,
+		},
+		{
+			name:     "pinned list selects first found",
+			pinned:   "youki, crun,runc",
+			strategy: FallbackChain(SelectPinned(), SelectHighestPriority),
+			want:     Crun,
+		},
+		{
+			name:     "pinned runtime missing falls through to capability tier",
+			pinned:   Youki,
+			strategy: chain,
+			want:     Crun,
+		},
+		{
+			name:     "health-filtered tier",
+			strategy: FallbackChain(SelectPinned(), SelectWithCapability("sdNotify", "true"), SelectMatching(healthy), SelectHighestPriority),
+			want:     Runc,
+		},
+		{
+			name:     "raw priority tier",
+			strategy: FallbackChain(SelectPinned(), SelectMatching(func(Runtime) bool { return false }), SelectHighestPriority),
+			want:     Containerd,
+		},
+		{
+			name:     "no tier matches",
+			strategy: FallbackChain(SelectNamed(Youki)),
+			wantErr:  ErrNoRuntimeSelected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTC_RUNTIME", tt.pinned)
+
+			result, err := detector.DetectWithStrategy(context.Background(), tt.strategy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DetectWithStrategy() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(result.Runtimes) != 3 {
+				t.Errorf("expected 3 runtimes, got %d", len(result.Runtimes))
+			}
+			if result.Selected == nil || result.Selected.Name != tt.want {
+				t.Errorf("Selected = %v, want %s", result.Selected, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetector_DetectWithStrategy_Hooks(t *testing.T) {
+	t.Parallel()
+
+	oci := &fakeOCIDetector{runtimes: []Runtime{
+		{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+		{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+	}}
+
+	var found []string
+	var hooked string
+	detector := NewDetector(oci, nil, nil, WithOverride(Crun), WithoutHostChecks(),
+		WithOnRuntimeFound(func(rt Runtime) { found = append(found, rt.Name) }),
+		WithResultHook(func(r *Result) error {
+			hooked = r.Selected.Name
+			return nil
+		}))
+
+	result, err := detector.DetectWithStrategy(context.Background(), FallbackChain(detector.SelectPinned(), SelectHighestPriority))
+	if err != nil {
+		t.Fatalf("DetectWithStrategy() error = %v", err)
+	}
+	if result.Selected.Name != Crun {
+		t.Errorf("Selected = %s, want %s", result.Selected.Name, Crun)
+	}
+	// The hook sees the pinned selection, and the callback every runtime found
+	if hooked != Crun {
+		t.Errorf("hook saw Selected = %q, want %q", hooked, Crun)
+	}
+	if len(found) != 2 {
+		t.Errorf("callback saw %v, want runc and crun", found)
+	}
+
+	failing := NewDetector(oci, nil, nil, WithoutHostChecks(),
+		WithResultHook(func(*Result) error { return errors.New("rejected") }))
+	if _, err := failing.DetectWithStrategy(context.Background(), SelectHighestPriority); err == nil {
+		t.Error("DetectWithStrategy() error = nil, want hook failure")
+	}
+}
+
+func TestDetector_SelectPinned(t *testing.T) {
+	t.Setenv("OTC_RUNTIME", Runc)
+
+	oci := &fakeOCIDetector{runtimes: []Runtime{
+		{Name: Runc, Type: TypeOCI, Priority: PriorityOCI},
+		{Name: Crun, Type: TypeOCI, Priority: PriorityOCI},
+	}}
+
+	tests := []struct {
+		name     string
+		override string
+		want     string
+	}{
+		{name: "override beats environment", override: Crun, want: Crun},
+		{name: "first found list entry", override: "youki,crun,runc", want: Crun},
+		{name: "empty override selects none", override: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewDetector(oci, nil, nil, WithOverride(tt.override))
+
+			result, err := detector.DetectWithStrategy(context.Background(), FallbackChain(detector.SelectPinned(), SelectNamed(Youki)))
+			if tt.want == "" {
+				if !errors.Is(err, ErrNoRuntimeSelected) {
+					t.Fatalf("DetectWithStrategy() error = %v, want %v", err, ErrNoRuntimeSelected)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DetectWithStrategy() error = %v", err)
+			}
+			if result.Selected.Name != tt.want {
+				t.Errorf("Selected = %s, want %s", result.Selected.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestResult_SelectBy(t *testing.T) {
+	t.Parallel()
+
+	result := NewResult([]Runtime{
+		{Name: Runc, Type: TypeOCI, Version: "1.1.12", Priority: PriorityOCI},
+		{Name: Crun, Type: TypeOCI, Version: "1.14.4", Priority: PriorityOCI},
+		{Name: Containerd, Type: TypeCRI, Version: "1.7.13", Priority: PriorityCRI},
+		{Name: Youki, Type: TypeOCI, Version: unknownVersion, Priority: PriorityOCI},
+	}, nil)
+
+	tests := []struct {
+		name     string
+		strategy SelectionStrategy
+		want     string // Empty means no selection
+	}{
+		{name: "highest priority", strategy: SelectHighestPriority, want: Containerd},
+		{name: "newest version", strategy: SelectNewestVersion, want: Crun},
+		{name: "by type", strategy: SelectByType(TypeOCI), want: Runc},
+		{name: "by missing type", strategy: SelectByType(TypePodman), want: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := result.SelectBy(tt.strategy)
+			switch {
+			case tt.want == "" && got != nil:
+				t.Errorf("SelectBy() = %s, want nil", got.Name)
+			case tt.want != "" && (got == nil || got.Name != tt.want):
+				t.Errorf("SelectBy() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if result.Selected.Name != Containerd {
+		t.Errorf("Selected = %s, want SelectBy to leave it unchanged", result.Selected.Name)
+	}
+}
+
+func TestSelectNewestVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		runtimes []Runtime
+		wantPath string // Empty means no selection
+	}{
+		{
+			name: "tie goes to higher priority",
+			runtimes: []Runtime{
+				{Name: Runc, Version: "1.1.12", Path: "/usr/local/bin/runc"},
+				{Name: Runc, Version: "v1.1.12", Path: "/usr/bin/runc"},
+			},
+			wantPath: "/usr/local/bin/runc",
+		},
+		{
+			name: "release beats pre-release",
+			runtimes: []Runtime{
+				{Name: Runc, Version: "1.2.0-rc.1", Path: "/opt/runc"},
+				{Name: Runc, Version: "1.2.0", Path: "/usr/bin/runc"},
+			},
+			wantPath: "/usr/bin/runc",
+		},
+		{
+			name: "wasm runtime never selected",
+			runtimes: []Runtime{
+				{Name: WasmEdge, Type: TypeWasm, Version: "2.0.0", Path: "/usr/bin/wasmedge"},
+				{Name: Runc, Type: TypeOCI, Version: "1.1.12", Path: "/usr/bin/runc"},
+			},
+			wantPath: "/usr/bin/runc",
+		},
+		{
+			name:     "only wasm runtimes",
+			runtimes: []Runtime{{Name: WasmEdge, Type: TypeWasm, Version: "0.14.1"}},
+		},
+		{
+			name:     "only unparseable versions",
+			runtimes: []Runtime{{Name: Runc, Version: unknownVersion}},
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := SelectNewestVersion(tt.runtimes)
+			switch {
+			case tt.wantPath == "" && got != nil:
+				t.Errorf("SelectNewestVersion() = %s, want nil", got.Path)
+			case tt.wantPath != "" && (got == nil || got.Path != tt.wantPath):
+				t.Errorf("SelectNewestVersion() = %v, want %s", got, tt.wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String returns a one-line summary of the runtime for logs and CLI output,
+// e.g. "runc (oci) v1.1.12 at /usr/bin/runc [priority 70]". Only numeric
+// versions get a "v" prefix, so runsc's "release-20240101.0" is shown as is.
+// A missing or unparseable version is shown as "version unknown", and
+// " at <path>" is left out when Path is empty.
+func (r Runtime) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)", r.Name, r.Type)
+
+	switch {
+	case r.Version == "" || r.Version == unknownVersion:
+		b.WriteString(" version unknown")
+	case r.Version[0] >= '0' && r.Version[0] <= '9':
+		fmt.Fprintf(&b, " v%s", r.Version)
+	default:
+		fmt.Fprintf(&b, " %s", r.Version)
+	}
+
+	if r.Path != "" {
+		fmt.Fprintf(&b, " at %s", r.Path)
+	}
+	fmt.Fprintf(&b, " [priority %d]", r.Priority)
+	return b.String()
+}
+
+// String returns a one-line summary of the result: the selected runtime,
+// followed by how many alternatives were found and how many warnings were
+// raised, e.g. "runc (oci) v1.1.12 at /usr/bin/runc [priority 70]; 1 alternative, 0 warnings".
+func (r *Result) String() string {
+	selected := "no runtime detected"
+	alternatives := len(r.Runtimes)
+	if r.Selected != nil {
+		selected = r.Selected.String()
+		alternatives--
+	}
+	return fmt.Sprintf("%s; %s, %s", selected,
+		plural(alternatives, "alternative"), plural(len(r.Warnings), "warning"))
+}
+
+// plural formats n with noun, adding an "s" unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,93 @@
+package runtime
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRuntime_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rt   Runtime
+		want string
+	}{
+		{
+			name: "full",
+			rt:   Runtime{Name: Runc, Type: TypeOCI, Version: "1.1.12", Path: "/usr/bin/runc", Priority: PriorityOCI},
+			want: "runc (oci) v1.1.12 at /usr/bin/runc [priority 70]",
+		},
+		{
+			name: "version already prefixed",
+			rt:   Runtime{Name: Containerd, Type: TypeCRI, Version: "v1.7.2", Path: "/run/containerd/containerd.sock", Priority: PriorityCRI},
+			want: "containerd (cri) v1.7.2 at /run/containerd/containerd.sock [priority 100]",
+		},
+		{
+			name: "release version",
+			rt:   Runtime{Name: Runsc, Type: TypeOCI, Version: "release-20240101.0", Path: "/usr/local/bin/runsc", Priority: PriorityOCI},
+			want: "runsc (oci) release-20240101.0 at /usr/local/bin/runsc [priority 70]",
+		},
+		{
+			name: "unknown version",
+			rt:   Runtime{Name: Crun, Type: TypeOCI, Version: unknownVersion, Path: "/usr/bin/crun", Priority: PriorityOCI},
+			want: "crun (oci) version unknown at /usr/bin/crun [priority 70]",
+		},
+		{
+			name: "no version or path",
+			rt:   Runtime{Name: Podman, Type: TypePodman},
+			want: "podman (podman) version unknown [priority 0]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.rt.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResult_String(t *testing.T) {
+	t.Parallel()
+
+	runc := Runtime{Name: Runc, Type: TypeOCI, Version: "1.1.12", Path: "/usr/bin/runc", Priority: PriorityOCI}
+	crun := Runtime{Name: Crun, Type: TypeOCI, Version: "1.14", Path: "/usr/bin/crun", Priority: PriorityOCI - 1}
+
+	tests := []struct {
+		name   string
+		result *Result
+		want   string
+	}{
+		{
+			name:   "selected with alternative",
+			result: NewResult([]Runtime{runc, crun}, nil),
+			want:   "runc (oci) v1.1.12 at /usr/bin/runc [priority 70]; 1 alternative, 0 warnings",
+		},
+		{
+			name:   "single runtime with warnings",
+			result: NewResult([]Runtime{runc}, []error{errors.New("a"), errors.New("b")}),
+			want:   "runc (oci) v1.1.12 at /usr/bin/runc [priority 70]; 0 alternatives, 2 warnings",
+		},
+		{
+			name:   "empty",
+			result: NewResult(nil, []error{errors.New("not found")}),
+			want:   "no runtime detected; 0 alternatives, 1 warning",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.result.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package runtime
+
+import "strings"
+
+// maxSuggestDistance is the largest edit distance at which a name is offered
+// as a "did you mean" suggestion. Two edits cover a transposed pair of letters.
+const maxSuggestDistance = 2
+
+// suggestName returns the candidate closest to name by edit distance, ignoring
+// case, or "" if none is within maxSuggestDistance. Ties go to the earlier candidate.
+func suggestName(name string, candidates []string) string {
+	name = strings.ToLower(name)
+	best, bestDist := "", maxSuggestDistance+1
+	for _, c := range candidates {
+		if dist := levenshtein(name, strings.ToLower(c)); dist < bestDist {
+			best, bestDist = c, dist
+		}
+	}
+	return best
+}
+
+// levenshtein returns the number of single-byte insertions, deletions, and
+// substitutions needed to turn a into b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
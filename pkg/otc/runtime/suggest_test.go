@@ -0,0 +1,58 @@
+package runtime
+
+import "testing"
+
+func TestLevenshtein(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "runc", b: "", want: 4},
+		{a: "", b: "crun", want: 4},
+		{a: "runc", b: "runc", want: 0},
+		{a: "contaierd", b: "containerd", want: 1},
+		{a: "docekr", b: "docker", want: 2},
+		{a: "kitten", b: "sitting", want: 3},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.a+"->"+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			if got := levenshtein(tt.a, tt.b); got != tt.want {
+				t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "contaierd", want: Containerd},
+		{name: "docekr", want: Docker},
+		{name: "Runc", want: Runc},
+		{name: "cri-o", want: CRIO},
+		{name: "rkt", want: ""},
+		{name: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := suggestName(tt.name, validRuntimeNames); got != tt.want {
+				t.Errorf("suggestName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
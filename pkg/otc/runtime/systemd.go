@@ -0,0 +1,129 @@
+package runtime
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// systemdUnits maps daemon-based runtimes to the systemd units that run them
+var systemdUnits = map[string]string{
+	Containerd: "containerd.service",
+	CRIO:       "crio.service",
+}
+
+// restrictiveUnitProperties are systemd sandboxing settings that can prevent
+// a runtime daemon from creating namespaces, mounts, or devices.
+var restrictiveUnitProperties = []string{
+	"ProtectSystem",
+	"ProtectHome",
+	"NoNewPrivileges",
+	"PrivateDevices",
+	"ProtectKernelTunables",
+	"ProtectKernelModules",
+	"ProtectControlGroups",
+	"RestrictNamespaces",
+}
+
+// checkSystemdSandbox inspects the systemd units of detected daemon runtimes
+// and returns a warning for each unit running under restrictive settings.
+// Best-effort: returns nil when systemd is not present.
+func checkSystemdSandbox(ctx context.Context, runtimes []Runtime) []error {
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return nil
+	}
+
+	var warnings []error
+	for _, rt := range runtimes {
+		unit, ok := systemdUnits[rt.Name]
+		if !ok {
+			continue
+		}
+
+		output, err := exec.CommandContext(ctx, "systemctl", "show", unit,
+			"--property="+strings.Join(restrictiveUnitProperties, ",")).Output()
+		if err != nil {
+			continue // Unit unknown or systemd not running
+		}
+
+		if restrictions := parseUnitRestrictions(string(output)); len(restrictions) > 0 {
+			warnings = append(warnings, newWarning(SeverityMedium, "%s runs under restrictive systemd settings: %s",
+				unit, strings.Join(restrictions, ", ")))
+		}
+	}
+
+	return warnings
+}
+
+// parseUnitRestrictions returns the restrictive "Key=value" pairs from `systemctl show` output.
+// Properties that are unset or disabled ("no", "false") are ignored.
+func parseUnitRestrictions(output string) []string {
+	var restrictions []string
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+
+		switch value {
+		case "", "no", "false":
+			continue
+		}
+
+		for _, prop := range restrictiveUnitProperties {
+			if key == prop {
+				restrictions = append(restrictions, key+"="+value)
+				break
+			}
+		}
+	}
+	return restrictions
+}
+
+// checkServiceState records the systemd state of detected daemon runtimes in
+// Capabilities["serviceState"] (`systemctl is-active`, e.g. "active", "failed")
+// and Capabilities["serviceEnabled"] (`systemctl is-enabled`, e.g. "enabled").
+// A failed unit yields a warning. Best-effort: returns nil when systemd is not present.
+func checkServiceState(ctx context.Context, runtimes []Runtime) []error {
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return nil
+	}
+
+	var warnings []error
+	for i := range runtimes {
+		unit, ok := systemdUnits[runtimes[i].Name]
+		if !ok {
+			continue
+		}
+
+		states := map[string]string{
+			"serviceState":   systemctlQuery(ctx, "is-active", unit),
+			"serviceEnabled": systemctlQuery(ctx, "is-enabled", unit),
+		}
+		for capability, state := range states {
+			if state == "" {
+				continue
+			}
+			if runtimes[i].Capabilities == nil {
+				runtimes[i].Capabilities = make(map[string]string)
+			}
+			runtimes[i].Capabilities[capability] = state
+		}
+
+		if states["serviceState"] == "failed" {
+			warnings = append(warnings, newWarning(SeverityMedium,
+				"%s is in failed state; %s may stop responding", unit, runtimes[i].Name))
+		}
+	}
+
+	return warnings
+}
+
+// systemctlQuery runs `systemctl <verb> <unit>` and returns the first line of output.
+// The is-active and is-enabled verbs exit non-zero for inactive or disabled units
+// but still print the state, so the exit status is ignored.
+func systemctlQuery(ctx context.Context, verb, unit string) string {
+	output, _ := exec.CommandContext(ctx, "systemctl", verb, unit).Output()
+	first, _, _ := strings.Cut(string(output), "\n")
+	return strings.TrimSpace(first)
+}
@@ -0,0 +1,169 @@
+package runtime
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseUnitRestrictions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{
+			name:   "unrestricted unit",
+			output: "ProtectSystem=no\nProtectHome=no\nNoNewPrivileges=no\nRestrictNamespaces=no\n",
+			want:   nil,
+		},
+		{
+			name:   "restricted unit",
+			output: "ProtectSystem=strict\nProtectHome=no\nNoNewPrivileges=yes\nPrivateDevices=no\n",
+			want:   []string{"ProtectSystem=strict", "NoNewPrivileges=yes"},
+		},
+		{
+			name:   "unrelated properties ignored",
+			output: "Description=containerd container runtime\nMemoryLimit=infinity\n",
+			want:   nil,
+		},
+		{
+			name:   "empty output",
+			output: "",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := parseUnitRestrictions(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseUnitRestrictions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSystemdSandbox(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	runtimes := []Runtime{
+		{Name: Containerd, Type: TypeCRI},
+		{Name: Runc, Type: TypeOCI},
+	}
+
+	t.Run("restrictive unit warns", func(t *testing.T) {
+		binDir := t.TempDir()
+		writeFakeBinary(t, binDir, "systemctl", `printf 'ProtectSystem=full\nNoNewPrivileges=yes\nProtectHome=no\n'`)
+		t.Setenv("PATH", binDir)
+
+		warnings := checkSystemdSandbox(context.Background(), runtimes)
+		if len(warnings) != 1 {
+			t.Fatalf("expected 1 warning, got %d: %v", len(warnings), warnings)
+		}
+		want := "containerd.service runs under restrictive systemd settings: ProtectSystem=full, NoNewPrivileges=yes"
+		if warnings[0].Error() != want {
+			t.Errorf("warning = %q, want %q", warnings[0].Error(), want)
+		}
+		if sev := WarningSeverity(warnings[0]); sev != SeverityMedium {
+			t.Errorf("severity = %v, want %v", sev, SeverityMedium)
+		}
+	})
+
+	t.Run("unrestricted unit", func(t *testing.T) {
+		binDir := t.TempDir()
+		writeFakeBinary(t, binDir, "systemctl", `printf 'ProtectSystem=no\nNoNewPrivileges=no\n'`)
+		t.Setenv("PATH", binDir)
+
+		if warnings := checkSystemdSandbox(context.Background(), runtimes); len(warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", warnings)
+		}
+	})
+
+	t.Run("systemd not present", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		if warnings := checkSystemdSandbox(context.Background(), runtimes); warnings != nil {
+			t.Errorf("expected nil warnings, got %v", warnings)
+		}
+	})
+}
+
+func TestCheckServiceState(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	tests := []struct {
+		name        string
+		script      string
+		wantCaps    map[string]string
+		wantWarning bool
+	}{
+		{
+			name: "active and enabled",
+			script: `case "$1" in
+is-active) echo active ;;
+is-enabled) echo enabled ;;
+esac`,
+			wantCaps:    map[string]string{"serviceState": "active", "serviceEnabled": "enabled"},
+			wantWarning: false,
+		},
+		{
+			name: "inactive and disabled",
+			script: `case "$1" in
+is-active) echo inactive; exit 3 ;;
+is-enabled) echo disabled; exit 1 ;;
+esac`,
+			wantCaps:    map[string]string{"serviceState": "inactive", "serviceEnabled": "disabled"},
+			wantWarning: false,
+		},
+		{
+			name: "failed unit warns",
+			script: `case "$1" in
+is-active) echo failed; exit 3 ;;
+is-enabled) echo enabled ;;
+esac`,
+			wantCaps:    map[string]string{"serviceState": "failed", "serviceEnabled": "enabled"},
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binDir := t.TempDir()
+			writeFakeBinary(t, binDir, "systemctl", tt.script)
+			t.Setenv("PATH", binDir)
+
+			runtimes := []Runtime{
+				{Name: Containerd, Type: TypeCRI},
+				{Name: Runc, Type: TypeOCI},
+			}
+
+			warnings := checkServiceState(context.Background(), runtimes)
+			if got := len(warnings) > 0; got != tt.wantWarning {
+				t.Errorf("checkServiceState() warnings = %v, wantWarning %v", warnings, tt.wantWarning)
+			}
+			if !reflect.DeepEqual(runtimes[0].Capabilities, tt.wantCaps) {
+				t.Errorf("containerd Capabilities = %v, want %v", runtimes[0].Capabilities, tt.wantCaps)
+			}
+			if runtimes[1].Capabilities != nil {
+				t.Errorf("runc Capabilities = %v, want nil", runtimes[1].Capabilities)
+			}
+		})
+	}
+
+	t.Run("systemd not present", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		runtimes := []Runtime{{Name: Containerd, Type: TypeCRI}}
+		if warnings := checkServiceState(context.Background(), runtimes); warnings != nil {
+			t.Errorf("expected nil warnings, got %v", warnings)
+		}
+		if runtimes[0].Capabilities != nil {
+			t.Errorf("Capabilities = %v, want nil", runtimes[0].Capabilities)
+		}
+	})
+}
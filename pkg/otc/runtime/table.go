@@ -0,0 +1,43 @@
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// ToTable writes the detected runtimes as an aligned table with the columns
+// NAME, TYPE, VERSION, PATH, PRIORITY, and SELECTED, in Result.Runtimes order.
+// The selected runtime is marked with "*"; missing versions show as "-".
+func (r *Result) ToTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "NAME\tTYPE\tVERSION\tPATH\tPRIORITY\tSELECTED")
+	for i := range r.Runtimes {
+		rt := &r.Runtimes[i]
+
+		version := rt.Version
+		if version == "" {
+			version = "-"
+		}
+
+		selected := ""
+		if r.isSelected(rt) {
+			selected = "*"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			rt.Name, rt.Type, version, rt.Path, rt.Priority, selected)
+	}
+
+	return tw.Flush()
+}
+
+// isSelected reports whether rt is the selected runtime. Selected normally points
+// into Runtimes, but copies (e.g. hand-built results) are matched by name and path.
+func (r *Result) isSelected(rt *Runtime) bool {
+	if r.Selected == nil {
+		return false
+	}
+	return r.Selected == rt || (r.Selected.Name == rt.Name && r.Selected.Path == rt.Path)
+}
@@ -0,0 +1,59 @@
+package runtime
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResult_ToTable(t *testing.T) {
+	t.Parallel()
+
+	runtimes := []Runtime{
+		{Name: Containerd, Type: TypeCRI, Version: "1.7.0", Path: "/run/containerd/containerd.sock", Priority: PriorityCRI},
+		{Name: Runc, Type: TypeOCI, Version: "1.1.12", Path: "/usr/bin/runc", Priority: PriorityOCI},
+		{Name: WasmEdge, Type: TypeWasm, Path: "/usr/local/bin/wasmedge", Priority: PriorityWasm},
+	}
+
+	tests := []struct {
+		name   string
+		result *Result
+		want   string
+	}{
+		{
+			name:   "runtimes with selection",
+			result: &Result{Runtimes: runtimes, Selected: &runtimes[0]},
+			want: `NAME        TYPE  VERSION  PATH                             PRIORITY  SELECTED
+containerd  cri   1.7.0    /run/containerd/containerd.sock  100       *
+runc        oci   1.1.12   /usr/bin/runc                    70        
+wasmedge    wasm  -        /usr/local/bin/wasmedge          10        
+`,
+		},
+		{
+			name:   "selection copied from another result",
+			result: &Result{Runtimes: runtimes[1:2], Selected: &Runtime{Name: Runc, Path: "/usr/bin/runc"}},
+			want: `NAME  TYPE  VERSION  PATH           PRIORITY  SELECTED
+runc  oci   1.1.12   /usr/bin/runc  70        *
+`,
+		},
+		{
+			name:   "no runtimes",
+			result: &Result{},
+			want:   "NAME  TYPE  VERSION  PATH  PRIORITY  SELECTED\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b strings.Builder
+			if err := tt.result.ToTable(&b); err != nil {
+				t.Fatalf("ToTable() error = %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("ToTable() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package runtime
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultDetectTimeout bounds each call a detector makes to a runtime.
+const defaultDetectTimeout = 5 * time.Second
+
+// getTimeoutFromEnv reads the OTC_DETECT_TIMEOUT environment variable as a Go
+// duration (e.g. "10s"). Returns def when unset. Invalid or non-positive values
+// also return def, along with a low-severity warning.
+func getTimeoutFromEnv(def time.Duration) (time.Duration, error) {
+	raw := strings.TrimSpace(os.Getenv("OTC_DETECT_TIMEOUT"))
+	if raw == "" {
+		return def, nil
+	}
+
+	timeout, err := time.ParseDuration(raw)
+	if err != nil || timeout <= 0 {
+		return def, newWarning(SeverityLow, "invalid OTC_DETECT_TIMEOUT %q, using %s", raw, def)
+	}
+	return timeout, nil
+}
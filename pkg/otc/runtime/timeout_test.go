@@ -0,0 +1,117 @@
+package runtime
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetTimeoutFromEnv(t *testing.T) {
+	// Modifies OTC_DETECT_TIMEOUT, so can't run parallel
+
+	tests := []struct {
+		name        string
+		value       string
+		want        time.Duration
+		wantWarning bool
+	}{
+		{name: "unset", value: "", want: defaultDetectTimeout},
+		{name: "valid duration", value: "10s", want: 10 * time.Second},
+		{name: "whitespace trimmed", value: " 1m30s ", want: 90 * time.Second},
+		{name: "not a duration", value: "ten", want: defaultDetectTimeout, wantWarning: true},
+		{name: "missing unit", value: "10", want: defaultDetectTimeout, wantWarning: true},
+		{name: "negative", value: "-5s", want: defaultDetectTimeout, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTC_DETECT_TIMEOUT", tt.value)
+
+			got, err := getTimeoutFromEnv(defaultDetectTimeout)
+			if got != tt.want {
+				t.Errorf("getTimeoutFromEnv() = %v, want %v", got, tt.want)
+			}
+			if (err != nil) != tt.wantWarning {
+				t.Errorf("getTimeoutFromEnv() error = %v, wantWarning %v", err, tt.wantWarning)
+			}
+			if err != nil && !isWarning(err) {
+				t.Errorf("getTimeoutFromEnv() error = %T, want *Warning", err)
+			}
+		})
+	}
+}
+
+func TestNewContainerdDetector_TimeoutFromEnv(t *testing.T) {
+	// Modifies OTC_DETECT_TIMEOUT, so can't run parallel
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Setenv("OTC_DETECT_TIMEOUT", "12s")
+
+		if d := NewContainerdDetector(); d.timeout != 12*time.Second {
+			t.Errorf("timeout = %v, want 12s", d.timeout)
+		}
+	})
+
+	t.Run("option overrides env", func(t *testing.T) {
+		t.Setenv("OTC_DETECT_TIMEOUT", "bogus")
+
+		d := NewContainerdDetector(WithTimeout(time.Second))
+		if d.timeout != time.Second || d.timeoutWarning != nil {
+			t.Errorf("timeout = %v, warning = %v; want 1s and no warning", d.timeout, d.timeoutWarning)
+		}
+	})
+
+	t.Run("invalid value warns on detect", func(t *testing.T) {
+		t.Setenv("OTC_DETECT_TIMEOUT", "bogus")
+
+		d := NewContainerdDetector()
+		if d.timeout != defaultDetectTimeout {
+			t.Errorf("timeout = %v, want %v", d.timeout, defaultDetectTimeout)
+		}
+
+		d.socketPaths = []string{startFakeCRIServer(t, &fakeRuntimeService{version: "1.7.0"}, nil)}
+		runtimes, err := d.Detect(context.Background())
+		if len(runtimes) != 1 || !isWarning(err) {
+			t.Errorf("Detect() = %v, %v; want containerd with a warning", runtimes, err)
+		}
+	})
+}
+
+func TestNewPodmanDetector_TimeoutFromEnv(t *testing.T) {
+	// Modifies OTC_DETECT_TIMEOUT and XDG_RUNTIME_DIR, so can't run parallel
+
+	t.Setenv("OTC_DETECT_TIMEOUT", "bogus")
+	t.Setenv("XDG_RUNTIME_DIR", "")
+
+	d := NewPodmanDetector().(*podmanDetector)
+	if d.timeout != defaultDetectTimeout {
+		t.Errorf("timeout = %v, want %v", d.timeout, defaultDetectTimeout)
+	}
+
+	d.rootfulSocket = filepath.Join(t.TempDir(), "podman.sock")
+	startFakePodmanAPI(t, d.rootfulSocket, "4.9.3")
+	runtimes, err := d.Detect(context.Background())
+	if len(runtimes) != 1 || !isWarning(err) {
+		t.Errorf("Detect() = %v, %v; want podman with a warning", runtimes, err)
+	}
+}
+
+func TestNewDockerDetector_TimeoutFromEnv(t *testing.T) {
+	// Modifies OTC_DETECT_TIMEOUT and DOCKER_HOST, so can't run parallel
+
+	t.Setenv("OTC_DETECT_TIMEOUT", "bogus")
+
+	d := NewDockerDetector().(*dockerDetector)
+	if d.timeout != defaultDetectTimeout {
+		t.Errorf("timeout = %v, want %v", d.timeout, defaultDetectTimeout)
+	}
+
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	startFakeDockerAPI(t, socket, "27.3.1")
+	t.Setenv("DOCKER_HOST", "unix://"+socket)
+	runtimes, err := d.Detect(context.Background())
+	if len(runtimes) != 1 || !isWarning(err) {
+		t.Errorf("Detect() = %v, %v; want docker with a warning", runtimes, err)
+	}
+}
@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// tomlEntry is one line of interest in a TOML config: a key assignment, or a
+// table header when key is empty. Table names and keys are normalized by
+// tomlKeyReplacer, so quoting and spacing don't matter.
+type tomlEntry struct {
+	table string // Enclosing table, "" for top-level keys
+	key   string // Key as written, possibly dotted
+	value string // Value with whitespace and surrounding quotes removed
+}
+
+// name returns the entry's full dotted key, e.g. "debug.level" for level
+// under [debug], or the table name for a header.
+func (e tomlEntry) name() string {
+	switch {
+	case e.key == "":
+		return e.table
+	case e.table == "":
+		return e.key
+	default:
+		return e.table + "." + e.key
+	}
+}
+
+// tomlKeyReplacer normalizes a TOML table name or dotted key for comparison.
+var tomlKeyReplacer = strings.NewReplacer(" ", "", "\t", "", `"`, "", "'", "")
+
+// readTOMLEntries reads the table headers and single-line key assignments of
+// the TOML file at path, in order, with comments removed. This covers the
+// settings otc reads from daemon configs without a full TOML parser;
+// multi-line values are not understood.
+// Returns error if the file can't be read.
+func readTOMLEntries(path string) ([]tomlEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var entries []tomlEntry
+	var table string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripTOMLComment(scanner.Text()))
+		if strings.HasPrefix(line, "[") {
+			table = tomlKeyReplacer.Replace(strings.Trim(line, "[]"))
+			entries = append(entries, tomlEntry{table: table})
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		entries = append(entries, tomlEntry{
+			table: table,
+			key:   tomlKeyReplacer.Replace(key),
+			value: strings.Trim(strings.TrimSpace(value), `"'`),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// stripTOMLComment removes a trailing # comment from a TOML line, leaving #
+// inside quoted strings alone.
+func stripTOMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,82 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadTOMLEntries(t *testing.T) {
+	t.Parallel()
+
+	config := `version = 2 # schema
+root = "/data/containerd"
+
+[debug] # daemon logging
+  level = "debug" # verbose
+
+[plugins."io.containerd.grpc.v1.cri".containerd]
+  default_runtime_name = 'crun'
+  sandbox_image = "example.com/pause#3.9"
+  runtimes.runc.runtime_type = "io.containerd.runc.v2"
+`
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	entries, err := readTOMLEntries(configPath)
+	if err != nil {
+		t.Fatalf("readTOMLEntries() error = %v", err)
+	}
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.name()+"="+e.value)
+	}
+	want := []string{
+		"version=2",
+		"root=/data/containerd",
+		"debug=",
+		"debug.level=debug",
+		"plugins.io.containerd.grpc.v1.cri.containerd=",
+		"plugins.io.containerd.grpc.v1.cri.containerd.default_runtime_name=crun",
+		"plugins.io.containerd.grpc.v1.cri.containerd.sandbox_image=example.com/pause#3.9",
+		"plugins.io.containerd.grpc.v1.cri.containerd.runtimes.runc.runtime_type=io.containerd.runc.v2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readTOMLEntries() = %q, want %q", got, want)
+	}
+
+	if _, err := readTOMLEntries(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Error("readTOMLEntries() on missing file: expected error")
+	}
+}
+
+func TestStripTOMLComment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: `default_runtime_name = "crun"`, want: `default_runtime_name = "crun"`},
+		{line: `default_runtime_name = "crun"  # comment`, want: `default_runtime_name = "crun"  `},
+		{line: `[plugins.cri.containerd] # comment`, want: `[plugins.cri.containerd] `},
+		{line: `sandbox_image = "example.com/pause#3.9" # comment`, want: `sandbox_image = "example.com/pause#3.9" `},
+		{line: `sandbox_image = 'example.com/pause#3.9'`, want: `sandbox_image = 'example.com/pause#3.9'`},
+		{line: `# whole line`, want: ``},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.line, func(t *testing.T) {
+			t.Parallel()
+
+			if got := stripTOMLComment(tt.line); got != tt.want {
+				t.Errorf("stripTOMLComment(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,16 +3,27 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// ErrDetectorClosed is returned by Detect after Close has been called.
+var ErrDetectorClosed = errors.New("detector is closed")
+
 // Type represents the category of container runtime.
 type Type string
 
 const (
-	// TypeOCI represents direct OCI runtimes (runc, crun, youki)
+	// TypeOCI represents direct OCI runtimes (runc, crun, youki, runsc)
 	TypeOCI Type = "oci"
 
 	// TypeCRI represents Container Runtime Interface implementations (containerd, CRI-O)
@@ -23,35 +34,79 @@ const (
 
 	// TypeDocker represents Docker runtime (backward compatibility)
 	TypeDocker Type = "docker"
+
+	// TypeWasm represents standalone WebAssembly runtimes (wasmedge)
+	TypeWasm Type = "wasm"
 )
 
 // Runtime contains information about a detected container runtime.
 type Runtime struct {
 	// Name is the runtime identifier (e.g., "runc", "containerd", "crio")
-	Name string
+	Name string `json:"name"`
 
 	// Type is the category of runtime
-	Type Type
+	Type Type `json:"type"`
 
 	// Version is the runtime version string
-	Version string
+	Version string `json:"version"`
 
 	// Path is the filesystem path to the runtime
 	// For binaries: executable path (e.g., "/usr/bin/runc")
 	// For socket-based runtimes: socket path (e.g., "unix:///run/containerd/containerd.sock")
-	Path string
+	Path string `json:"path"`
 
 	// Priority determines selection order when multiple runtimes are available.
 	// Higher values indicate higher priority.
-	Priority int
+	Priority int `json:"priority"`
+
+	// Commit is the source revision the runtime was built from, if reported
+	Commit string `json:"commit,omitempty"`
+
+	// SpecVersion is the OCI runtime spec version the runtime implements,
+	// from the "spec:" line of its --version output, if reported. For
+	// containerd it is the spec version its release generates (see
+	// containerdSpecVersion)
+	SpecVersion string `json:"specVersion,omitempty"`
+
+	// CgroupVersion is the host's cgroup version (1 or 2), which decides how
+	// an OCI runtime applies resource limits; 0 if unknown or not an OCI runtime
+	CgroupVersion int `json:"cgroupVersion,omitempty"`
+
+	// Rootless is true when the runtime runs without root privileges, e.g. a
+	// containerd or Podman daemon whose socket is in the user's runtime
+	// directory ($XDG_RUNTIME_DIR or /run/user/<uid>)
+	Rootless bool `json:"rootless"`
+
+	// Handlers lists the runtime handlers a CRI runtime reports in its Status
+	// response (e.g. "runc", "kata", "runsc"), usable as RuntimeClass handler
+	// values. The unnamed default handler is reported as "default".
+	Handlers []string `json:"handlers,omitempty"`
+
+	// APIVersion is the CRI API version a CRI runtime serves (e.g. "v1"),
+	// from its Version response; empty for other runtime types
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// DefaultHandler is the runtime handler a CRI runtime launches containers
+	// with when none is requested (e.g. "runc", "crun", "kata"), read from the
+	// containerd config; empty if unknown
+	DefaultHandler string `json:"defaultHandler,omitempty"`
+
+	// Socket describes the ownership of the local socket a daemon was found
+	// on; nil for binaries and remote endpoints
+	Socket *SocketInfo `json:"socket,omitempty"`
+
+	// Capabilities holds optional features and settings discovered by probes
+	// (e.g., "nvidiaReady": "true"). Absent keys mean the value is unknown.
+	Capabilities map[string]string `json:"capabilities,omitempty"`
 }
 
 // Priority constants for runtime selection.
 const (
 	PriorityCRI    = 100 // Production Kubernetes (containerd, CRI-O)
-	PriorityOCI    = 70  // Direct OCI runtimes (runc, crun, youki)
+	PriorityOCI    = 70  // Direct OCI runtimes (runc, crun, youki, runsc)
 	PriorityPodman = 50  // Podman
 	PriorityDocker = 30  // Docker (backward compatibility)
+	PriorityWasm   = 10  // Standalone Wasm runtimes (informational only)
 )
 
 // Runtime name constants for OTC_RUNTIME environment variable.
@@ -59,10 +114,23 @@ const (
 	Runc       = "runc"
 	Crun       = "crun"
 	Youki      = "youki"
+	Runsc      = "runsc"
 	Containerd = "containerd"
 	CRIO       = "crio"
 	Podman     = "podman"
 	Docker     = "docker"
+	WasmEdge   = "wasmedge"
+)
+
+// Mode describes how the selected runtime was chosen.
+type Mode string
+
+const (
+	// ModeAuto means all runtimes were detected and the best one selected
+	ModeAuto Mode = "auto"
+
+	// ModeOverride means detection was pinned to one runtime via OTC_RUNTIME
+	ModeOverride Mode = "override"
 )
 
 // Result contains the results of runtime detection.
@@ -70,25 +138,72 @@ type Result struct {
 	// Runtimes is the list of all detected runtimes, ordered by priority (highest first)
 	Runtimes []Runtime
 
-	// Selected is the highest priority runtime (nil if no runtimes detected)
+	// Selected is the highest priority runtime other than a standalone Wasm
+	// runtime (nil if none detected)
 	Selected *Runtime
 
+	// Mode records whether the result comes from full detection or an override
+	Mode Mode
+
+	// KubernetesNode is true when the host shows signs of running a kubelet.
+	// On Kubernetes nodes, CRI runtimes are the ones that matter most.
+	KubernetesNode bool
+
+	// DetectedAt is when detection started. Zero for results built with
+	// NewResult or ResultBuilder rather than returned by a Detector.
+	DetectedAt time.Time
+
+	// Duration is how long detection took, including the health checks
+	// reported as warnings but not result hooks
+	Duration time.Duration
+
 	// Warnings contains non-fatal errors from individual detectors.
 	// Detection continues even if some detectors fail.
 	// Empty if all detectors succeeded.
 	Warnings []error
 }
 
+// IsEmpty returns true if no runtimes were detected.
+func (r *Result) IsEmpty() bool {
+	return len(r.Runtimes) == 0
+}
+
 // HasWarnings returns true if any detector encountered non-fatal errors.
 func (r *Result) HasWarnings() bool {
 	return len(r.Warnings) > 0
 }
 
-// OCIDetector finds OCI-compliant runtime binaries (runc, crun, youki).
+// WasOverridden returns true if detection was pinned to a runtime via OTC_RUNTIME.
+func (r *Result) WasOverridden() bool {
+	return r.Mode == ModeOverride
+}
+
+// RuntimeCount returns the number of detected runtimes.
+func (r *Result) RuntimeCount() int {
+	return len(r.Runtimes)
+}
+
+// CountByType returns the number of detected runtimes per type.
+// Only types with at least one runtime are present in the map.
+func (r *Result) CountByType() map[Type]int {
+	counts := make(map[Type]int)
+	for _, rt := range r.Runtimes {
+		counts[rt.Type]++
+	}
+	return counts
+}
+
+// OCIDetector finds OCI-compliant runtime binaries (runc, crun, youki, runsc).
 // Implementations search system PATH for runtime executables.
+//
+// Any detector may return runtimes together with a *Warning error to report a
+// non-fatal problem; the runtimes are kept and the warning added to Result.Warnings.
+// Runtimes returned with any other error are discarded.
 type OCIDetector interface {
 	// Detect finds all available OCI runtime binaries.
-	Detect() ([]Runtime, error)
+	// Context bounds the probe commands; a binary still running when it
+	// expires is killed and reported as not detected.
+	Detect(ctx context.Context) ([]Runtime, error)
 }
 
 // CRIDetector finds CRI socket-based runtimes (containerd, CRI-O).
@@ -106,27 +221,91 @@ type PodmanDetector interface {
 	Detect(ctx context.Context) ([]Runtime, error)
 }
 
+// DockerDetector finds Docker Engine installations.
+type DockerDetector interface {
+	// Detect finds available Docker runtimes.
+	// Context is used for socket connection timeouts.
+	Detect(ctx context.Context) ([]Runtime, error)
+}
+
 // Detector orchestrates runtime detection across all types.
 type Detector struct {
-	oci      OCIDetector
-	cri      CRIDetector
-	podman   PodmanDetector
-	override string // If set, only detect this specific runtime
+	oci          OCIDetector
+	cri          CRIDetector
+	podman       PodmanDetector
+	docker       DockerDetector // Set with WithDockerDetector
+	override     string         // If set, only detect this specific runtime
+	overridePath string         // Binary for an OCI override, skipping the PATH search
+	strict       bool           // If set, tied top-priority runtimes are an error
+
+	emptyResults bool // If set, finding nothing yields an empty Result, not an error
+
+	nameFilter  *regexp.Regexp        // If set, only matching runtime names are kept
+	minVersions map[string]string     // Minimum version by runtime name
+	priorities  map[string]int        // Priority overrides by runtime name
+	hooks       []func(*Result) error // Run on the result before Detect returns
+	order       []Type                // Detector run order; nil means defaultDetectorOrder
+
+	onFound   func(Runtime) // Called as each runtime is confirmed
+	onFoundMu sync.Mutex    // Serializes onFound calls
+
+	kubernetes   kubernetesProbe
+	noHostChecks bool         // Set with WithoutHostChecks
+	logger       *slog.Logger // Set with WithLogger; nil logs nothing
+	metrics      MetricsHook  // Set with WithMetrics; nil reports nothing
+
+	closeOnce sync.Once
+	closed    atomic.Bool
+	closeErr  error
 }
 
 // NewDetector creates a new runtime detector with the provided implementations.
 // Pass nil for any detector type not needed.
 //
 // The detector automatically reads the OTC_RUNTIME environment variable.
-// If set, only the specified runtime will be detected.
-// Valid values: runc, crun, youki, containerd, crio, podman, docker
-func NewDetector(oci OCIDetector, cri CRIDetector, podman PodmanDetector) *Detector {
-	return &Detector{
-		oci:      oci,
-		cri:      cri,
-		podman:   podman,
-		override: getOverrideFromEnv(),
+// If set, only the specified runtime will be detected. A comma-separated list,
+// such as "crun,runc", detects each listed runtime and selects the first found.
+// Valid values: runc, crun, youki, runsc, containerd, crio, podman, docker
+// (see ValidRuntimeNames). Use NewValidatedDetector to reject an invalid value
+// at construction.
+//
+// OTC_RUNTIME_PATH may name the binary to use when OTC_RUNTIME is a single OCI
+// runtime, skipping the PATH search; it is ignored otherwise.
+//
+// A Docker detector is not taken positionally; add one with WithDockerDetector.
+func NewDetector(oci OCIDetector, cri CRIDetector, podman PodmanDetector, opts ...DetectorOption) *Detector {
+	d := &Detector{
+		oci:          oci,
+		cri:          cri,
+		podman:       podman,
+		override:     getOverrideFromEnv(),
+		overridePath: getOverridePathFromEnv(),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	// Pass the logger on after all options, so detectors added by options get it too
+	if d.logger != nil {
+		for _, sub := range []any{d.oci, d.cri, d.podman, d.docker} {
+			if s, ok := sub.(loggerSetter); ok {
+				s.setLogger(d.logger)
+			}
+		}
+	}
+	return d
+}
+
+// NewValidatedDetector is NewDetector, but checks the override (OTC_RUNTIME or
+// WithOverride) at construction so a misconfigured value surfaces immediately
+// rather than on the first Detect call.
+// Returns error wrapping ErrInvalidOverride if the override names no known runtime.
+func NewValidatedDetector(oci OCIDetector, cri CRIDetector, podman PodmanDetector, opts ...DetectorOption) (*Detector, error) {
+	d := NewDetector(oci, cri, podman, opts...)
+	if err := ValidateOverride(d.override); err != nil {
+		return nil, err
 	}
+	return d, nil
 }
 
 // Detect finds all available container runtimes on the system.
@@ -136,120 +315,553 @@ func NewDetector(oci OCIDetector, cri CRIDetector, podman PodmanDetector) *Detec
 //
 // If OTC_RUNTIME environment variable is set, only the specified runtime is detected.
 // Returns error if the specified runtime is not found.
+//
+// With WithEmptyResults, finding nothing returns a non-nil Result whose
+// IsEmpty reports true, and errors are reserved for fatal failures.
 func (d *Detector) Detect(ctx context.Context) (*Result, error) {
+	if d.closed.Load() {
+		return nil, ErrDetectorClosed
+	}
+
+	start := time.Now()
+	result, err := d.detect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.stamp(start)
+
+	if err := d.runHooks(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// runHooks runs the result hooks in registration order, stopping at the first error.
+func (d *Detector) runHooks(result *Result) error {
+	for _, hook := range d.hooks {
+		if err := hook(result); err != nil {
+			return fmt.Errorf("result hook failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// detect runs automatic or OTC_RUNTIME detection.
+func (d *Detector) detect(ctx context.Context) (*Result, error) {
 	// If override is set, only detect that runtime
 	if d.override != "" {
 		return d.detectOverride(ctx)
 	}
 
+	runtimes, warnings := d.collect(ctx)
+
+	// If no runtimes found, and we have warnings, return the first error
+	if len(runtimes) == 0 && len(warnings) > 0 && !d.emptyResults {
+		return nil, warnings[0]
+	}
+
+	if d.strict {
+		if err := checkAmbiguous(runtimes); err != nil {
+			return nil, err
+		}
+	}
+
+	// Select highest priority runtime
+	result := NewResult(runtimes, warnings)
+	if result.Selected == nil && len(result.Runtimes) > 0 {
+		// Only standalone Wasm runtimes, which can't run containers
+		return nil, ErrNoRuntimeSelected
+	}
+	result.KubernetesNode = d.isKubernetesNode()
+
+	return result, nil
+}
+
+// Close releases resources held by the detector and its sub-detectors.
+// Sub-detectors implementing io.Closer are closed; their errors are joined.
+// Close is safe to call multiple times and returns the same result each time.
+// Detect must not be called after Close; it returns ErrDetectorClosed.
+func (d *Detector) Close() error {
+	d.closeOnce.Do(func() {
+		d.closed.Store(true)
+
+		var errs []error
+		for _, sub := range []any{d.oci, d.cri, d.podman, d.docker} {
+			if closer, ok := sub.(io.Closer); ok {
+				errs = append(errs, closer.Close())
+			}
+		}
+		d.closeErr = errors.Join(errs...)
+	})
+	return d.closeErr
+}
+
+// collect runs every configured detector and returns the detected runtimes
+// sorted by priority, along with non-fatal errors from individual detectors.
+func (d *Detector) collect(ctx context.Context) ([]Runtime, []error) {
 	var runtimes []Runtime
 	var warnings []error
 
-	// Detect OCI runtimes (no context needed for PATH lookups)
-	if d.oci != nil {
-		oci, err := d.oci.Detect()
+	// Run each configured detector in order (OCI, CRI, Podman, Docker by default)
+	for _, typ := range d.detectorOrder() {
+		detect := d.detectorFor(typ)
+		if detect == nil {
+			continue
+		}
+
+		found, err := d.runDetector(ctx, typ, detect)
 		if err != nil {
 			warnings = append(warnings, err)
-		} else {
-			runtimes = append(runtimes, oci...)
+		}
+		if err == nil || isWarning(err) {
+			d.notifyFound(found)
+			runtimes = append(runtimes, found...)
 		}
 	}
 
-	// Detect CRI runtimes (context for socket operations)
-	if d.cri != nil {
-		cri, err := d.cri.Detect(ctx)
-		if err != nil {
-			warnings = append(warnings, err)
-		} else {
-			runtimes = append(runtimes, cri...)
+	// Collapse runtimes reported by more than one detector
+	runtimes = dedupByName(runtimes)
+
+	// Apply caller priorities and filters
+	runtimes, excluded := d.applyFilters(runtimes)
+	warnings = append(warnings, excluded...)
+
+	return runtimes, append(warnings, d.checkRuntimes(ctx, runtimes)...)
+}
+
+// checkRuntimes annotates the runtimes left after filtering and returns
+// warnings from the cross-runtime, configuration, and host checks. Both
+// automatic detection and OTC_RUNTIME overrides run it.
+func (d *Detector) checkRuntimes(ctx context.Context, runtimes []Runtime) []error {
+	// Cross-reference OCI spec ranges between runtimes
+	annotateInterchangeable(runtimes)
+
+	// Flag pause images that would break every pod
+	warnings := checkSandboxImage(runtimes)
+
+	// Flag daemons left running with debug logging
+	warnings = append(warnings, checkLogLevel(runtimes)...)
+
+	// Flag storage filesystems that break overlay snapshots
+	warnings = append(warnings, checkBackingFs(runtimes)...)
+
+	return append(warnings, d.hostChecks(ctx, runtimes)...)
+}
+
+// applyFilters applies the caller's priority overrides, sorts runtimes by
+// priority (highest first), and drops runtimes excluded by the name filter or
+// their minimum version. Each filter that excluded runtimes adds a
+// low-severity warning naming them.
+func (d *Detector) applyFilters(runtimes []Runtime) ([]Runtime, []error) {
+	applyPriorityOverrides(runtimes, d.priorities)
+	sortByPriority(runtimes)
+
+	var warnings []error
+
+	// Drop runtimes excluded by the name filter
+	if d.nameFilter != nil {
+		var excluded []string
+		runtimes, excluded = filterByName(runtimes, d.nameFilter)
+		if len(excluded) > 0 {
+			warnings = append(warnings, newWarning(SeverityLow,
+				"name filter %q excluded runtimes: %s", d.nameFilter, strings.Join(excluded, ", ")))
 		}
 	}
 
-	// Detect Podman (context for socket operations)
-	if d.podman != nil {
-		podman, err := d.podman.Detect(ctx)
-		if err != nil {
-			warnings = append(warnings, err)
-		} else {
-			runtimes = append(runtimes, podman...)
+	// Drop runtimes older than their configured minimum
+	if len(d.minVersions) > 0 {
+		var excluded []string
+		runtimes, excluded = filterByMinVersion(runtimes, d.minVersions)
+		if len(excluded) > 0 {
+			warnings = append(warnings, newWarning(SeverityLow,
+				"minimum version excluded runtimes: %s", strings.Join(excluded, ", ")))
 		}
 	}
 
-	// If no runtimes found, and we have warnings, return the first error
-	if len(runtimes) == 0 && len(warnings) > 0 {
-		return nil, warnings[0]
+	return runtimes, warnings
+}
+
+// hostChecks runs the checks that consult the host rather than the detected
+// runtimes alone: systemd units, /proc, /sys, the kubelet config, and the
+// configured OCI binaries. Runtimes reached over ssh:// are skipped.
+// Returns nil with WithoutHostChecks.
+func (d *Detector) hostChecks(ctx context.Context, runtimes []Runtime) []error {
+	if d.noHostChecks {
+		return nil
 	}
 
-	// Sort by priority (highest first)
-	sortByPriority(runtimes)
+	// Runtimes on another host can't be judged by this one. The checks may
+	// annotate Capabilities, so local runtimes are copied back afterwards.
+	var local []Runtime
+	var indexes []int
+	for i, rt := range runtimes {
+		if !rt.isRemote() {
+			local = append(local, rt)
+			indexes = append(indexes, i)
+		}
+	}
+	warnings := d.localHostChecks(ctx, local)
+	for j, i := range indexes {
+		runtimes[i] = local[j]
+	}
+	return warnings
+}
+
+// localHostChecks runs the hostChecks on runtimes that live on this host.
+func (d *Detector) localHostChecks(ctx context.Context, runtimes []Runtime) []error {
+	// Flag daemons whose systemd sandboxing may break container operations
+	warnings := checkSystemdSandbox(ctx, runtimes)
 
-	result := &Result{
-		Runtimes: runtimes,
-		Warnings: warnings,
+	// Report whether daemon services are running and flag failed units
+	warnings = append(warnings, checkServiceState(ctx, runtimes)...)
+
+	// Flag rootless runtimes that can't launch containers under no_new_privs
+	warnings = append(warnings, checkNoNewPrivs(runtimes, "/proc", os.Geteuid())...)
+
+	// Flag OCI binaries configured in a CRI runtime that differ from PATH
+	warnings = append(warnings, checkVersionSkew(ctx, runtimes)...)
+
+	// Flag runtimes that leave containers unconfined on AppArmor hosts
+	warnings = append(warnings, checkAppArmor(runtimes, "/sys")...)
+
+	// Flag runtime/kubelet cgroup driver mismatches
+	warnings = append(warnings, checkCgroupDriver(runtimes, d.kubernetes)...)
+
+	// Report image GC thresholds and flag extreme ones
+	warnings = append(warnings, checkImageGC(runtimes, d.kubernetes)...)
+
+	return warnings
+}
+
+// isKubernetesNode reports whether the host is a Kubernetes node, or false
+// with WithoutHostChecks.
+func (d *Detector) isKubernetesNode() bool {
+	return !d.noHostChecks && d.kubernetes.isNode()
+}
+
+// log returns the configured logger, or a no-op one.
+func (d *Detector) log() *slog.Logger {
+	return loggerOrDiscard(d.logger)
+}
+
+// runDetector runs one sub-detector, logging and reporting metrics for the run.
+func (d *Detector) runDetector(ctx context.Context, typ Type, detect func(context.Context) ([]Runtime, error)) ([]Runtime, error) {
+	d.log().Debug("running detector", "type", typ)
+	start := time.Now()
+	found, err := detect(ctx)
+	d.observeRun(typ, time.Since(start), found, err)
+	d.logOutcome(typ, found, err)
+	return found, err
+}
+
+// logOutcome logs what a detector run found, or why it failed.
+func (d *Detector) logOutcome(typ Type, found []Runtime, err error) {
+	names := make([]string, 0, len(found))
+	for _, rt := range found {
+		names = append(names, rt.Name)
+	}
+	if err != nil {
+		d.log().Debug("detector finished with error", "type", typ, "found", names, "error", err)
+		return
 	}
+	d.log().Debug("detector finished", "type", typ, "found", names)
+}
 
-	// Select highest priority runtime
-	if len(runtimes) > 0 {
-		result.Selected = &runtimes[0]
+// notifyFound passes newly confirmed runtimes to the WithOnRuntimeFound callback.
+// Calls are serialized so the callback never runs concurrently with itself.
+func (d *Detector) notifyFound(runtimes []Runtime) {
+	if d.onFound == nil {
+		return
 	}
+	d.onFoundMu.Lock()
+	defer d.onFoundMu.Unlock()
 
-	return result, nil
+	for _, rt := range runtimes {
+		d.onFound(rt)
+	}
 }
 
-// detectOverride detects only the runtime specified in OTC_RUNTIME.
+// defaultDetectorOrder is the sequence detectors run in unless WithDetectorOrder is set.
+var defaultDetectorOrder = []Type{TypeOCI, TypeCRI, TypePodman, TypeDocker}
+
+// detectorOrder returns the configured detector types followed by any
+// remaining default types, without duplicates.
+func (d *Detector) detectorOrder() []Type {
+	if len(d.order) == 0 {
+		return defaultDetectorOrder
+	}
+
+	seen := make(map[Type]bool)
+	order := make([]Type, 0, len(defaultDetectorOrder))
+	for _, typ := range append(append([]Type(nil), d.order...), defaultDetectorOrder...) {
+		if !seen[typ] {
+			seen[typ] = true
+			order = append(order, typ)
+		}
+	}
+	return order
+}
+
+// detectorFor returns the detection function for a runtime type,
+// or nil if no detector is configured for it.
+func (d *Detector) detectorFor(typ Type) func(context.Context) ([]Runtime, error) {
+	switch typ {
+	case TypeOCI:
+		if d.oci != nil {
+			return d.oci.Detect
+		}
+	case TypeCRI:
+		if d.cri != nil {
+			return d.cri.Detect
+		}
+	case TypePodman:
+		if d.podman != nil {
+			return d.podman.Detect
+		}
+	case TypeDocker:
+		if d.docker != nil {
+			return d.docker.Detect
+		}
+	}
+	return nil
+}
+
+// detectOverride detects only the runtimes named in OTC_RUNTIME. With a list,
+// every listed runtime is detected and the first one found in list order is selected.
+// The runtimes found go through the same deduplication, filters, and checks as
+// automatic detection.
 func (d *Detector) detectOverride(ctx context.Context) (*Result, error) {
+	names := parseOverride(d.override)
+	if len(names) == 0 {
+		return nil, ValidateRuntimeName(d.override)
+	}
+	for _, name := range names {
+		if err := ValidateRuntimeName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Run each needed detector once, even if several listed runtimes share it
 	var runtimes []Runtime
-	var err error
+	var warnings, failures []error
+	ran := make(map[Type]bool)
+	for _, name := range names {
+		typ, detect, err := d.overrideDetector(name, len(names) == 1)
+		if err != nil && len(names) == 1 {
+			return nil, err
+		}
+		if err != nil {
+			// Another listed runtime may still be found
+			failures = append(failures, err)
+			continue
+		}
+		if ran[typ] {
+			continue
+		}
+		ran[typ] = true
+
+		found, err := d.runDetector(ctx, typ, detect)
+		if err != nil && !isWarning(err) {
+			failures = append(failures, &DetectionError{Runtime: name, Err: err})
+			continue
+		}
+		if err != nil {
+			warnings = append(warnings, err)
+		}
+		d.notifyFound(found)
+		runtimes = append(runtimes, found...)
+	}
 
-	// Determine which detector to use based on override value
-	switch d.override {
-	case Runc, Crun, Youki:
+	// Filter to only the requested runtimes, then apply the caller's filters
+	var filtered []Runtime
+	for _, rt := range dedupByName(runtimes) {
+		if slices.Contains(names, rt.Name) {
+			filtered = append(filtered, rt)
+		}
+	}
+	filtered, excluded := d.applyFilters(filtered)
+
+	if len(filtered) == 0 {
+		notFound := fmt.Errorf("runtime %s %w on system", strings.Join(names, ", "), ErrRuntimeNotFound)
+		if len(excluded) > 0 {
+			// Found, but rejected by a filter: say which rather than "not found"
+			notFound = fmt.Errorf("%w: %w", notFound, errors.Join(excluded...))
+			failures = append(failures, notFound)
+		}
+		switch len(failures) {
+		case 0:
+			return d.overrideNotFound(notFound)
+		case 1:
+			return d.overrideNotFound(failures[0])
+		default:
+			return d.overrideNotFound(errors.Join(failures...))
+		}
+	}
+
+	warnings = append(warnings, failures...)
+	warnings = append(warnings, excluded...)
+	warnings = append(warnings, d.checkRuntimes(ctx, filtered)...)
+
+	result := NewResult(filtered, warnings)
+	result.Mode = ModeOverride
+	result.KubernetesNode = d.isKubernetesNode()
+
+	result.selectInListOrder(names)
+
+	return result, nil
+}
+
+// overrideDetector returns the detector type and function that find the
+// override runtime name. OTC_RUNTIME_PATH is honored only when name is the sole override.
+// Returns error if the detector is not configured or OTC_RUNTIME_PATH is not executable.
+func (d *Detector) overrideDetector(name string, sole bool) (Type, func(context.Context) ([]Runtime, error), error) {
+	switch name {
+	case Runc, Crun, Youki, Runsc:
 		if d.oci == nil {
-			return nil, fmt.Errorf("OTC_RUNTIME=%s but OCI detector not configured", d.override)
+			return "", nil, fmt.Errorf("OTC_RUNTIME=%s but OCI %w", d.override, ErrDetectorNotConfigured)
 		}
-		runtimes, err = d.oci.Detect()
+		if d.overridePath == "" || !sole {
+			return TypeOCI, d.oci.Detect, nil
+		}
+		if err := checkExecutable(d.overridePath); err != nil {
+			return "", nil, fmt.Errorf("%w: OTC_RUNTIME_PATH=%s: %w", ErrInvalidOverride, d.overridePath, err)
+		}
+		return TypeOCI, d.detectOverridePath, nil
 
 	case Containerd, CRIO:
 		if d.cri == nil {
-			return nil, fmt.Errorf("OTC_RUNTIME=%s but CRI detector not configured", d.override)
+			return "", nil, fmt.Errorf("OTC_RUNTIME=%s but CRI %w", d.override, ErrDetectorNotConfigured)
 		}
-		runtimes, err = d.cri.Detect(ctx)
+		return TypeCRI, d.cri.Detect, nil
 
 	case Podman:
 		if d.podman == nil {
-			return nil, fmt.Errorf("OTC_RUNTIME=%s but Podman detector not configured", d.override)
+			return "", nil, fmt.Errorf("OTC_RUNTIME=%s but Podman %w", d.override, ErrDetectorNotConfigured)
 		}
-		runtimes, err = d.podman.Detect(ctx)
+		return TypePodman, d.podman.Detect, nil
 
 	case Docker:
-		return nil, fmt.Errorf("docker runtime not yet supported")
+		if d.docker == nil {
+			return "", nil, fmt.Errorf("OTC_RUNTIME=%s but Docker %w", d.override, ErrDetectorNotConfigured)
+		}
+		return TypeDocker, d.docker.Detect, nil
 
 	default:
-		return nil, fmt.Errorf("invalid OTC_RUNTIME value: %s (valid: runc, crun, youki, containerd, crio, podman)", d.override)
+		return "", nil, ValidateRuntimeName(name)
 	}
+}
 
+// detectOverridePath probes the OTC_RUNTIME_PATH binary as the OTC_RUNTIME
+// runtime instead of searching PATH. The OCI detector's probe settings are used
+// when it is the built-in one.
+func (d *Detector) detectOverridePath(ctx context.Context) ([]Runtime, error) {
+	oci, ok := d.oci.(*ociDetector)
+	if !ok {
+		oci = NewOCIDetector().(*ociDetector)
+	}
+	rt, err := oci.probeBinary(ctx, d.override, d.overridePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to detect runtime %s: %w", d.override, err)
+		return nil, err
 	}
+	return []Runtime{rt}, nil
+}
 
-	// Filter to only the requested runtime
-	var filtered []Runtime
-	for _, rt := range runtimes {
-		if rt.Name == d.override {
-			filtered = append(filtered, rt)
+// overrideNotFound reports that the OTC_RUNTIME runtime could not be detected:
+// as an error by default, or as a warning on an empty Result with WithEmptyResults.
+func (d *Detector) overrideNotFound(cause error) (*Result, error) {
+	if !d.emptyResults {
+		return nil, cause
+	}
+	result := NewResult(nil, []error{cause})
+	result.Mode = ModeOverride
+	result.KubernetesNode = d.isKubernetesNode()
+	return result, nil
+}
+
+// ValidateOverride checks an OTC_RUNTIME value, a runtime name or comma-separated
+// list of them, without running detection. It trims whitespace like the
+// environment lookup does; an empty value means no override.
+// Returns error if the value names an unknown or unsupported runtime.
+func ValidateOverride(value string) error {
+	value = strings.TrimSpace(value)
+	names := parseOverride(value)
+	if value != "" && len(names) == 0 {
+		return ValidateRuntimeName(value)
+	}
+	for _, name := range names {
+		if err := ValidateRuntimeName(name); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	if len(filtered) == 0 {
-		return nil, fmt.Errorf("runtime %s not found on system", d.override)
+// validRuntimeNames lists the runtimes OTC_RUNTIME may name.
+var validRuntimeNames = []string{Runc, Crun, Youki, Runsc, Containerd, CRIO, Podman, Docker}
+
+// ValidRuntimeNames returns the runtime names accepted by OTC_RUNTIME and
+// WithOverride. The returned slice is a copy and may be modified.
+func ValidRuntimeNames() []string {
+	return slices.Clone(validRuntimeNames)
+}
+
+// ValidateRuntimeName checks that name is one of ValidRuntimeNames.
+// Returns error wrapping ErrInvalidOverride, listing the valid names, if not.
+// The error suggests the closest valid name when the value looks like a typo.
+func ValidateRuntimeName(name string) error {
+	if slices.Contains(validRuntimeNames, name) {
+		return nil
+	}
+	valid := strings.Join(validRuntimeNames, ", ")
+	if suggestion := suggestName(name, validRuntimeNames); suggestion != "" {
+		return fmt.Errorf("%w: %s, did you mean '%s'? (valid: %s)", ErrInvalidOverride, name, suggestion, valid)
+	}
+	return fmt.Errorf("%w: %s (valid: %s)", ErrInvalidOverride, name, valid)
+}
+
+// getOverridePathFromEnv reads the OTC_RUNTIME_PATH environment variable.
+// Returns empty string if not set or if value is empty after trimming whitespace.
+func getOverridePathFromEnv() string {
+	return strings.TrimSpace(os.Getenv("OTC_RUNTIME_PATH"))
+}
+
+// checkExecutable returns error if path is not a regular file with an execute bit set.
+func checkExecutable(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
 	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("not a regular file")
+	}
+	if info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("not executable (mode %s)", info.Mode().Perm())
+	}
+	return nil
+}
 
-	result := &Result{
-		Runtimes: filtered,
-		Selected: &filtered[0],
+// parseOverride splits an OTC_RUNTIME value into its comma-separated runtime
+// names, trimming whitespace and dropping empty and repeated entries.
+func parseOverride(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
 	}
+	return names
+}
 
-	return result, nil
+// selectInListOrder orders Runtimes by the position of their name in names,
+// as listed in OTC_RUNTIME, and selects the first. The sort is stable, so
+// priority order breaks ties. Runtimes must be non-empty.
+func (r *Result) selectInListOrder(names []string) {
+	slices.SortStableFunc(r.Runtimes, func(a, b Runtime) int {
+		return slices.Index(names, a.Name) - slices.Index(names, b.Name)
+	})
+	r.Selected = &r.Runtimes[0]
 }
 
 // getOverrideFromEnv reads the OTC_RUNTIME environment variable.
@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResult_Counts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		runtimes   []Runtime
+		wantCount  int
+		wantByType map[Type]int
+	}{
+		{
+			name: "mixed types",
+			runtimes: []Runtime{
+				{Name: Containerd, Type: TypeCRI},
+				{Name: Runc, Type: TypeOCI},
+				{Name: Crun, Type: TypeOCI},
+				{Name: Podman, Type: TypePodman},
+			},
+			wantCount:  4,
+			wantByType: map[Type]int{TypeCRI: 1, TypeOCI: 2, TypePodman: 1},
+		},
+		{
+			name:       "empty result",
+			runtimes:   nil,
+			wantCount:  0,
+			wantByType: map[Type]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := &Result{Runtimes: tt.runtimes}
+			if got := result.RuntimeCount(); got != tt.wantCount {
+				t.Errorf("RuntimeCount() = %d, want %d", got, tt.wantCount)
+			}
+			if got := result.CountByType(); !reflect.DeepEqual(got, tt.wantByType) {
+				t.Errorf("CountByType() = %v, want %v", got, tt.wantByType)
+			}
+		})
+	}
+}
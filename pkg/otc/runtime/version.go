@@ -0,0 +1,115 @@
+package runtime
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed runtime version: a numeric core plus optional
+// pre-release identifiers. Build metadata is dropped, as it doesn't affect
+// precedence.
+type semver struct {
+	core [3]int
+	pre  []string
+}
+
+// parseSemver parses versions as produced by parseVersion, e.g. "1.7.13",
+// "1.2.3-rc1+git.abcdef", or "v2.0". Missing minor and patch components count
+// as zero. Returns error for empty or non-numeric versions.
+func parseSemver(version string) (semver, error) {
+	v := stripVersionPrefix(strings.TrimSpace(version))
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+
+	var s semver
+	core, pre, hasPre := strings.Cut(v, "-")
+	if hasPre {
+		if pre == "" {
+			return semver{}, fmt.Errorf("invalid version %q: empty pre-release", version)
+		}
+		s.pre = strings.Split(pre, ".")
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return semver{}, fmt.Errorf("invalid version %q: too many components", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, fmt.Errorf("invalid version %q", version)
+		}
+		s.core[i] = n
+	}
+	return s, nil
+}
+
+// compare returns -1, 0, or 1 following semantic versioning precedence:
+// cores compare numerically, and a pre-release sorts before its release.
+func (s semver) compare(o semver) int {
+	for i := range s.core {
+		if s.core[i] != o.core[i] {
+			return cmp.Compare(s.core[i], o.core[i])
+		}
+	}
+
+	switch {
+	case len(s.pre) == 0 && len(o.pre) == 0:
+		return 0
+	case len(s.pre) == 0:
+		return 1
+	case len(o.pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(s.pre) && i < len(o.pre); i++ {
+		if c := comparePreRelease(s.pre[i], o.pre[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(s.pre), len(o.pre))
+}
+
+// comparePreRelease compares one pre-release identifier: numeric identifiers
+// compare numerically and sort before alphanumeric ones, which compare lexically.
+func comparePreRelease(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// CompareVersion compares the runtime's Version with other using semantic
+// versioning precedence and returns -1, 0, or 1 as Version is older than,
+// equal to, or newer than other. A leading "v" is ignored, missing minor and
+// patch components count as zero, and build metadata is ignored.
+// Returns error if either version cannot be parsed, e.g. "" or "unknown".
+func (r Runtime) CompareVersion(other string) (int, error) {
+	mine, err := parseSemver(r.Version)
+	if err != nil {
+		return 0, fmt.Errorf("runtime %s: %w", r.Name, err)
+	}
+	theirs, err := parseSemver(other)
+	if err != nil {
+		return 0, err
+	}
+	return mine.compare(theirs), nil
+}
+
+// AtLeast reports whether the runtime's Version is minVersion or newer, e.g.
+// rt.AtLeast("1.6") for containerd 1.6+. Unparseable versions report false.
+func (r Runtime) AtLeast(minVersion string) bool {
+	c, err := r.CompareVersion(minVersion)
+	return err == nil && c >= 0
+}
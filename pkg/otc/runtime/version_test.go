@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"testing"
+)
+
+func TestRuntime_CompareVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version string
+		other   string
+		want    int
+		wantErr bool
+	}{
+		{name: "equal", version: "1.7.13", other: "1.7.13", want: 0},
+		{name: "older patch", version: "1.7.2", other: "1.7.13", want: -1},
+		{name: "newer major", version: "2.0.0", other: "1.7.13", want: 1},
+		{name: "missing components are zero", version: "1.6.0", other: "1.6", want: 0},
+		{name: "leading v ignored", version: "1.1.12", other: "v1.1.12", want: 0},
+		{name: "pre-release before release", version: "2.0.0-rc.1", other: "2.0.0", want: -1},
+		{name: "numeric pre-release order", version: "2.0.0-rc.2", other: "2.0.0-rc.10", want: -1},
+		{name: "numeric before alphanumeric", version: "1.0.0-1", other: "1.0.0-alpha", want: -1},
+		{name: "longer pre-release is newer", version: "1.0.0-alpha.1", other: "1.0.0-alpha", want: 1},
+		{name: "build metadata ignored", version: "1.2.3-rc1+git.abcdef", other: "1.2.3-rc1", want: 0},
+		{name: "empty version", version: "", other: "1.0", wantErr: true},
+		{name: "unknown version", version: unknownVersion, other: "1.0", wantErr: true},
+		{name: "unparseable other", version: "1.0.0", other: "latest", wantErr: true},
+		{name: "too many components", version: "1.2.3.4", other: "1.2.3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := Runtime{Name: Containerd, Version: tt.version}
+			got, err := rt.CompareVersion(tt.other)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompareVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CompareVersion() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntime_AtLeast(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		version    string
+		minVersion string
+		want       bool
+	}{
+		{name: "newer", version: "1.7.13", minVersion: "1.6", want: true},
+		{name: "equal", version: "1.6.0", minVersion: "1.6", want: true},
+		{name: "older", version: "1.5.18", minVersion: "1.6", want: false},
+		{name: "pre-release of minimum", version: "1.6.0-beta.1", minVersion: "1.6", want: false},
+		{name: "unparseable", version: "", minVersion: "1.6", want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := (Runtime{Version: tt.version}).AtLeast(tt.minVersion); got != tt.want {
+				t.Errorf("AtLeast(%q) = %v, want %v", tt.minVersion, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package runtime
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// criRuntimeBinaries returns the OCI runtime binaries configured for each
+// containerd runtime handler (containerd.runtimes.<handler>.options.BinaryName),
+// formatted as a sorted "handler=path" comma list. Handlers using the binary
+// from PATH have no BinaryName and are omitted. Returns "" when none is set.
+func criRuntimeBinaries(cfg criConfig) string {
+	value, _ := cfg.lookup("containerd", "runtimes")
+	handlers, _ := value.(map[string]any)
+
+	var entries []string
+	for handler := range handlers {
+		if binary := cfg.str("containerd", "runtimes", handler, "options", "BinaryName"); binary != "" {
+			entries = append(entries, handler+"="+binary)
+		}
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// checkVersionSkew warns when a CRI runtime is configured to run an OCI runtime
+// binary whose version differs from the same runtime found in PATH, e.g. after a
+// partial upgrade that replaced /usr/bin/runc but not /opt/bin/runc.
+// Configured binaries are matched to OCI runtimes by base name; binaries that
+// are the PATH runtime itself, or whose version can't be read, are skipped.
+func checkVersionSkew(ctx context.Context, runtimes []Runtime) []error {
+	inPath := make(map[string]Runtime)
+	for _, rt := range runtimes {
+		if rt.Type == TypeOCI {
+			inPath[rt.Name] = rt
+		}
+	}
+
+	oci := &ociDetector{}
+	var warnings []error
+	for _, rt := range runtimes {
+		binaries := rt.Capabilities["ociBinaries"]
+		if rt.Type != TypeCRI || binaries == "" {
+			continue
+		}
+
+		for _, entry := range strings.Split(binaries, ",") {
+			_, binary, _ := strings.Cut(entry, "=")
+			name := filepath.Base(binary)
+
+			other, ok := inPath[name]
+			if !ok || other.Path == binary {
+				continue
+			}
+
+			info, err := oci.extractVersion(ctx, name, binary)
+			if err != nil || info.version == other.Version {
+				continue
+			}
+
+			warnings = append(warnings, newWarning(SeverityMedium,
+				"%s is configured with %s %s at %s but PATH has %s %s at %s",
+				rt.Name, name, info.version, binary, name, other.Version, other.Path))
+		}
+	}
+	return warnings
+}
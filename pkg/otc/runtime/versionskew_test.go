@@ -0,0 +1,138 @@
+package runtime
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestCriRuntimeBinaries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{
+			name: "configured binaries",
+			config: `{"containerd": {"runtimes": {
+				"runc": {"options": {"BinaryName": "/opt/bin/runc", "SystemdCgroup": true}},
+				"crun": {"options": {"BinaryName": "/usr/local/bin/crun"}},
+				"kata": {"runtimeType": "io.containerd.kata.v2"}
+			}}}`,
+			want: "crun=/usr/local/bin/crun,runc=/opt/bin/runc",
+		},
+		{
+			name:   "binaries from PATH",
+			config: `{"containerd": {"runtimes": {"runc": {"options": {"SystemdCgroup": true}}}}}`,
+			want:   "",
+		},
+		{
+			name:   "no runtimes",
+			config: `{}`,
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := parseCRIConfig(map[string]string{"config": tt.config})
+			if err != nil {
+				t.Fatalf("parseCRIConfig() error = %v", err)
+			}
+			if got := criRuntimeBinaries(cfg); got != tt.want {
+				t.Errorf("criRuntimeBinaries() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckVersionSkew(t *testing.T) {
+	t.Parallel()
+
+	pathDir, optDir := t.TempDir(), t.TempDir()
+	pathRunc := writeFakeBinary(t, pathDir, Runc, `echo "runc version 1.1.12"`)
+	optRunc := writeFakeBinary(t, optDir, Runc, `echo "runc version 1.0.3"`)
+	sameRunc := writeFakeBinary(t, t.TempDir(), Runc, `echo "runc version 1.1.12"`)
+
+	inPath := Runtime{Name: Runc, Type: TypeOCI, Version: "1.1.12", Path: pathRunc}
+	containerd := func(binaries string) Runtime {
+		return Runtime{Name: Containerd, Type: TypeCRI, Capabilities: map[string]string{"ociBinaries": binaries}}
+	}
+
+	tests := []struct {
+		name         string
+		runtimes     []Runtime
+		wantWarnings int
+	}{
+		{
+			name:         "configured binary is older",
+			runtimes:     []Runtime{containerd("runc=" + optRunc), inPath},
+			wantWarnings: 1,
+		},
+		{
+			name:         "configured binary has same version",
+			runtimes:     []Runtime{containerd("runc=" + sameRunc), inPath},
+			wantWarnings: 0,
+		},
+		{
+			name:         "configured binary is the PATH one",
+			runtimes:     []Runtime{containerd("runc=" + pathRunc), inPath},
+			wantWarnings: 0,
+		},
+		{
+			name:         "runtime not in PATH",
+			runtimes:     []Runtime{containerd("runc=" + optRunc)},
+			wantWarnings: 0,
+		},
+		{
+			name:         "configured binary missing",
+			runtimes:     []Runtime{containerd("runc=" + filepath.Join(optDir, "missing", Runc)), inPath},
+			wantWarnings: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			warnings := checkVersionSkew(context.Background(), tt.runtimes)
+			if len(warnings) != tt.wantWarnings {
+				t.Fatalf("checkVersionSkew() = %v, want %d warnings", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestContainerdDetector_OCIBinariesLocalOnly(t *testing.T) {
+	t.Parallel()
+
+	svc := &fakeRuntimeService{
+		version: "1.7.13",
+		info:    map[string]string{"config": `{"containerd": {"runtimes": {"runc": {"options": {"BinaryName": "/opt/bin/runc"}}}}}`},
+	}
+	socket := startFakeCRIServer(t, svc, nil)
+
+	conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create gRPC client: %v", err)
+	}
+	defer closeConn(conn)
+
+	detector := NewContainerdDetector()
+	for _, remote := range []bool{false, true} {
+		caps, _ := detector.probeCapabilities(context.Background(), conn, remote, "", "")
+		_, ok := caps["ociBinaries"]
+		if ok == remote {
+			t.Errorf("probeCapabilities(remote=%v) ociBinaries present = %v, want %v", remote, ok, !remote)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// wasmEdgePluginPattern matches the plugin lines newer wasmedge releases print
+// after the version, e.g. ` (plugin "wasi_logging") version 0.1.0.0`.
+var wasmEdgePluginPattern = regexp.MustCompile(`\(plugin "([^"]+)"\)`)
+
+// detectWasmEdge finds a standalone wasmedge binary in PATH.
+// WasmEdge cannot run OCI bundles on its own, so it is reported with TypeWasm
+// and the lowest priority; it is never preferred over a real runtime.
+func (d *ociDetector) detectWasmEdge(ctx context.Context) (Runtime, error) {
+	pathEnv := os.Getenv("PATH")
+	cacheKey := WasmEdge + "\x00" + pathEnv
+	if d.negative.absent(cacheKey) {
+		return Runtime{}, fmt.Errorf("runtime %s %w in PATH: %w", WasmEdge, ErrRuntimeNotFound, exec.ErrNotFound)
+	}
+
+	path, err := exec.LookPath(WasmEdge)
+	if err != nil {
+		d.negative.record(cacheKey, filepath.SplitList(pathEnv))
+		return Runtime{}, fmt.Errorf("runtime %s %w in PATH: %w", WasmEdge, ErrRuntimeNotFound, err)
+	}
+
+	// WasmEdge has no features subcommand, so only the version banner is probed
+	info, err := d.extractVersion(ctx, WasmEdge, path)
+	if err != nil {
+		return Runtime{}, fmt.Errorf("failed to get version for %s: %w", WasmEdge, err)
+	}
+
+	return Runtime{
+		Name:         WasmEdge,
+		Type:         TypeWasm,
+		Version:      info.version,
+		Path:         path,
+		Priority:     PriorityWasm,
+		Commit:       info.commit,
+		Capabilities: info.caps,
+	}, nil
+}
+
+// parseWasmEdgeVersion parses `wasmedge --version`, which prints
+// "wasmedge version <version>" followed by one line per loaded plugin.
+// Plugin names are reported as a comma-separated "wasmPlugins" capability.
+func parseWasmEdgeVersion(output string) (string, map[string]string) {
+	first, rest, _ := strings.Cut(output, "\n")
+
+	caps := make(map[string]string)
+	var plugins []string
+	for _, match := range wasmEdgePluginPattern.FindAllStringSubmatch(rest, -1) {
+		plugins = append(plugins, match[1])
+	}
+	if len(plugins) > 0 {
+		caps["wasmPlugins"] = strings.Join(plugins, ",")
+	}
+
+	return parseVersion(first), caps
+}
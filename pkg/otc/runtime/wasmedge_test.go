@@ -0,0 +1,118 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseWasmEdgeVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		output      string
+		wantVersion string
+		wantCaps    map[string]string
+	}{
+		{
+			name:        "bare banner",
+			output:      "wasmedge version 0.13.5\n",
+			wantVersion: "0.13.5",
+			wantCaps:    map[string]string{},
+		},
+		{
+			name: "banner with plugins",
+			output: `wasmedge version 0.14.1
+ (plugin "wasi_logging") version 0.1.0.0
+ (plugin "wasi_nn") version 0.1.0.0
+`,
+			wantVersion: "0.14.1",
+			wantCaps:    map[string]string{"wasmPlugins": "wasi_logging,wasi_nn"},
+		},
+		{
+			name:        "unrecognized output",
+			output:      "WasmEdge runtime",
+			wantVersion: "",
+			wantCaps:    map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			version, caps := parseWasmEdgeVersion(tt.output)
+			if version != tt.wantVersion {
+				t.Errorf("parseWasmEdgeVersion() version = %q, want %q", version, tt.wantVersion)
+			}
+			if !reflect.DeepEqual(caps, tt.wantCaps) {
+				t.Errorf("parseWasmEdgeVersion() caps = %v, want %v", caps, tt.wantCaps)
+			}
+		})
+	}
+}
+
+func TestOCIDetector_Detect_WasmEdge(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	writeFakeBinary(t, binDir, WasmEdge,
+		`echo "wasmedge version 0.14.1"; echo ' (plugin "wasi_logging") version 0.1.0.0'`)
+	t.Setenv("PATH", binDir)
+
+	detector := &ociDetector{}
+	runtimes, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(runtimes) != 1 {
+		t.Fatalf("Detect() returned %d runtimes, want 1: %+v", len(runtimes), runtimes)
+	}
+
+	want := Runtime{
+		Name:         WasmEdge,
+		Type:         TypeWasm,
+		Version:      "0.14.1",
+		Path:         filepath.Join(binDir, WasmEdge),
+		Priority:     PriorityWasm,
+		Capabilities: map[string]string{"wasmPlugins": "wasi_logging"},
+	}
+	if !reflect.DeepEqual(runtimes[0], want) {
+		t.Errorf("Detect() = %+v, want %+v", runtimes[0], want)
+	}
+}
+
+func TestDetector_Detect_WasmEdgeOnly(t *testing.T) {
+	// Modifies PATH, so can't run parallel
+
+	binDir := t.TempDir()
+	writeFakeBinary(t, binDir, WasmEdge, `echo "wasmedge version 0.14.1"`)
+	t.Setenv("PATH", binDir)
+
+	detector := NewDetector(&ociDetector{}, nil, nil, WithOverride(""), WithoutHostChecks())
+
+	// A result with runtimes but nothing selected would leave Selected nil
+	// for callers that only check IsEmpty
+	if result, err := detector.Detect(context.Background()); !errors.Is(err, ErrNoRuntimeSelected) {
+		t.Fatalf("Detect() = %v, %v, want %v", result, err, ErrNoRuntimeSelected)
+	}
+
+	result, err := detector.DetectAll(context.Background())
+	if err != nil {
+		t.Fatalf("DetectAll() error = %v", err)
+	}
+	if result.RuntimeCount() != 1 || result.Runtimes[0].Name != WasmEdge {
+		t.Fatalf("Runtimes = %+v, want only %s", result.Runtimes, WasmEdge)
+	}
+	if result.Selected != nil {
+		t.Errorf("Selected = %+v, want nil", result.Selected)
+	}
+
+	if rt, err := detector.DetectFirst(context.Background()); !errors.Is(err, ErrRuntimeNotFound) {
+		t.Errorf("DetectFirst() = %v, %v, want %v", rt, err, ErrRuntimeNotFound)
+	}
+}